@@ -0,0 +1,451 @@
+// Package backup provides backup and restore of TeamSpeak 3 virtual servers.
+//
+// Each backup is an archive directory containing, for every online
+// virtual server, a snapshot plus JSON exports of its settings, channels,
+// groups and permissions, described by a manifest with checksums e.g.
+//
+//	m, err := backup.New(c, "/var/backups/ts3", backup.Retention(7, 0))
+//	if err != nil {
+//		log.Fatal(err)
+//	}
+//
+//	if err := m.Run(ctx, 24*time.Hour); err != nil {
+//		log.Fatal(err)
+//	}
+package backup
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/multiplay/go-ts3"
+)
+
+const (
+	// archiveTimeFormat is the format of archive directory names.
+	archiveTimeFormat = "20060102T150405Z"
+
+	// errEmptyResult is the ID of the error returned by the server when a
+	// list command has no results.
+	errEmptyResult = 1281
+)
+
+// server is the subset of ts3.ServerMethods used by Manager.
+type server interface {
+	WhoamiContext(ctx context.Context) (*ts3.ConnectionInfo, error)
+	UseContext(ctx context.Context, id int) error
+	ListContext(ctx context.Context, options ...string) ([]*ts3.Server, error)
+	InfoContext(ctx context.Context) (*ts3.Server, error)
+	CreateContext(ctx context.Context, name string, args ...ts3.CmdArg) (*ts3.CreatedServer, error)
+	SnapshotCreateContext(ctx context.Context, password string) (*ts3.Snapshot, error)
+	SnapshotDeployContext(ctx context.Context, snap *ts3.Snapshot, opts *ts3.SnapshotDeployOptions) (map[int]int, error)
+	ChannelListContext(ctx context.Context, options ...string) ([]*ts3.Channel, error)
+	GroupListContext(ctx context.Context) ([]*ts3.Group, error)
+	ChannelGroupListContext(ctx context.Context) ([]*ts3.ChannelGroup, error)
+	ServerGroupPermListContext(ctx context.Context, id int) ([]*ts3.PermissionValue, error)
+	ChannelGroupPermListContext(ctx context.Context, id int) ([]*ts3.PermissionValue, error)
+	ChannelPermListContext(ctx context.Context, id int) ([]*ts3.PermissionValue, error)
+}
+
+// Permissions is the permission export of a virtual server.
+// Each map is keyed by the ID of the group or channel.
+type Permissions struct {
+	ServerGroups  map[int][]*ts3.PermissionValue `json:"server_groups"`
+	ChannelGroups map[int][]*ts3.PermissionValue `json:"channel_groups"`
+	Channels      map[int][]*ts3.PermissionValue `json:"channels"`
+}
+
+// Manager creates, prunes and restores backup archives.
+type Manager struct {
+	srv      server
+	dir      string
+	keep     int
+	maxAge   time.Duration
+	password string
+	onError  func(error)
+	now      func() time.Time
+}
+
+// Retention sets how many archives are kept after each backup.
+// If keep is greater than 0 only the newest keep archives are kept and if
+// maxAge is greater than 0 archives older than maxAge are removed.
+// The newest archive is never removed. By default all archives are kept.
+func Retention(keep int, maxAge time.Duration) func(*Manager) error {
+	return func(m *Manager) error {
+		if keep < 0 || maxAge < 0 {
+			return fmt.Errorf("retention: invalid keep %d or max age %v", keep, maxAge)
+		}
+		m.keep = keep
+		m.maxAge = maxAge
+		return nil
+	}
+}
+
+// Password sets the password used to encrypt snapshots when creating
+// archives and to decrypt them when restoring.
+// Encrypted snapshots are only supported by newer servers.
+func Password(password string) func(*Manager) error {
+	return func(m *Manager) error {
+		m.password = password
+		return nil
+	}
+}
+
+// ErrorHandler sets the function called by Run when a backup fails.
+// By default errors are ignored and Run continues with the next backup.
+func ErrorHandler(f func(error)) func(*Manager) error {
+	return func(m *Manager) error {
+		m.onError = f
+		return nil
+	}
+}
+
+// New returns a new Manager which backs up the virtual servers of c to
+// archives in the directory dir.
+func New(c *ts3.Client, dir string, options ...func(*Manager) error) (*Manager, error) {
+	return newManager(c.Server, dir, options...)
+}
+
+// newManager returns a new Manager which uses srv.
+func newManager(srv server, dir string, options ...func(*Manager) error) (*Manager, error) {
+	m := &Manager{
+		srv:     srv,
+		dir:     dir,
+		onError: func(error) {},
+		now:     time.Now,
+	}
+	for _, f := range options {
+		if f == nil {
+			return nil, ts3.ErrNilOption
+		}
+		if err := f(m); err != nil {
+			return nil, err
+		}
+	}
+
+	return m, nil
+}
+
+// Run creates a backup immediately and then every interval until ctx is
+// done, which it returns. Failures, including archives skipped by Prune,
+// are passed to the error handler.
+func (m *Manager) Run(ctx context.Context, interval time.Duration) error {
+	if interval <= 0 {
+		return fmt.Errorf("run: invalid interval %v", interval)
+	}
+
+	t := time.NewTicker(interval)
+	defer t.Stop()
+
+	for {
+		if _, err := m.Create(ctx); err != nil && ctx.Err() == nil {
+			m.onError(err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-t.C:
+		}
+	}
+}
+
+// Create creates a new archive containing a backup of each online virtual
+// server, then applies the retention rules.
+// The virtual server selected before the backup is reselected afterwards.
+func (m *Manager) Create(ctx context.Context) (manifest *Manifest, err error) {
+	created := m.now().UTC()
+	name := created.Format(archiveTimeFormat)
+	if err = os.MkdirAll(m.dir, 0700); err != nil {
+		return nil, fmt.Errorf("create backup: %w", err)
+	}
+
+	// Names only have a resolution of a second so the temporary directory
+	// must not already exist, otherwise another backup is using it.
+	tmp := filepath.Join(m.dir, "."+name+".tmp")
+	if err = os.Mkdir(tmp, 0700); err != nil {
+		return nil, fmt.Errorf("create backup: %w", err)
+	}
+
+	// Remove partial archives on failure.
+	defer func() {
+		if err != nil {
+			os.RemoveAll(tmp) //nolint: errcheck
+		}
+	}()
+
+	manifest = &Manifest{
+		Version: FormatVersion,
+		Created: created,
+		dir:     tmp,
+	}
+	err = m.withServer(ctx, func() error {
+		servers, err := m.srv.ListContext(ctx)
+		if err != nil {
+			return err
+		}
+
+		for _, s := range servers {
+			if s.Status != "online" {
+				continue
+			}
+
+			b, err := m.backupServer(ctx, manifest.serverDir(s.ID), s.ID)
+			if err != nil {
+				return fmt.Errorf("backup server %d: %w", s.ID, err)
+			}
+			manifest.Servers = append(manifest.Servers, b)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err = writeFile(filepath.Join(tmp, ManifestFile), jsonWriter(manifest)); err != nil {
+		return nil, err
+	}
+
+	manifest.dir = filepath.Join(m.dir, name)
+	if err = os.Rename(tmp, manifest.dir); err != nil {
+		return nil, fmt.Errorf("create backup: %w", err)
+	}
+
+	if _, err = m.Prune(); err != nil {
+		return manifest, err
+	}
+
+	return manifest, nil
+}
+
+// withServer calls fn and then reselects the virtual server which was
+// selected before fn was called.
+func (m *Manager) withServer(ctx context.Context, fn func() error) (err error) {
+	info, err := m.srv.WhoamiContext(ctx)
+	if err != nil {
+		return err
+	}
+
+	defer func() {
+		if info.ServerID == 0 {
+			return
+		}
+
+		// Reselect even if ctx is done so the connection is left in a
+		// consistent state.
+		if err2 := m.srv.UseContext(context.Background(), info.ServerID); err2 != nil && err == nil {
+			err = err2
+		}
+	}()
+
+	return fn()
+}
+
+// backupServer writes the backup of the virtual server id to dir.
+func (m *Manager) backupServer(ctx context.Context, dir string, id int) (*ServerBackup, error) {
+	if err := os.Mkdir(dir, 0700); err != nil {
+		return nil, fmt.Errorf("backup: %w", err)
+	}
+
+	if err := m.srv.UseContext(ctx, id); err != nil {
+		return nil, err
+	}
+
+	info, err := m.srv.InfoContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	snap, err := m.srv.SnapshotCreateContext(ctx, m.password)
+	if err != nil {
+		return nil, err
+	}
+
+	channels, err := m.srv.ChannelListContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	groups, err := m.srv.GroupListContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	channelGroups, err := m.srv.ChannelGroupListContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	perms := &Permissions{
+		ServerGroups:  make(map[int][]*ts3.PermissionValue),
+		ChannelGroups: make(map[int][]*ts3.PermissionValue),
+		Channels:      make(map[int][]*ts3.PermissionValue),
+	}
+	for _, g := range groups {
+		if perms.ServerGroups[g.ID], err = permList(m.srv.ServerGroupPermListContext(ctx, g.ID)); err != nil {
+			return nil, err
+		}
+	}
+	for _, g := range channelGroups {
+		if perms.ChannelGroups[g.ID], err = permList(m.srv.ChannelGroupPermListContext(ctx, g.ID)); err != nil {
+			return nil, err
+		}
+	}
+	for _, c := range channels {
+		if perms.Channels[c.ID], err = permList(m.srv.ChannelPermListContext(ctx, c.ID)); err != nil {
+			return nil, err
+		}
+	}
+
+	b := &ServerBackup{
+		ID:               id,
+		Port:             info.Port,
+		Name:             info.Name,
+		UniqueIdentifier: info.UniqueIdentifier,
+		Encrypted:        m.password != "",
+		Files:            make(map[string]string),
+	}
+	for name, fn := range map[string]func(io.Writer) error{
+		SnapshotFile:         func(w io.Writer) error { _, err := snap.WriteTo(w); return err },
+		"serverinfo.json":    jsonWriter(info),
+		"channels.json":      jsonWriter(channels),
+		"servergroups.json":  jsonWriter(groups),
+		"channelgroups.json": jsonWriter(channelGroups),
+		"permissions.json":   jsonWriter(perms),
+	} {
+		if b.Files[name], err = writeFile(filepath.Join(dir, name), fn); err != nil {
+			return nil, err
+		}
+	}
+
+	return b, nil
+}
+
+// permList returns perms, treating an empty result as no permissions.
+func permList(perms []*ts3.PermissionValue, err error) ([]*ts3.PermissionValue, error) {
+	var e *ts3.Error
+	if errors.As(err, &e) && e.ID == errEmptyResult {
+		return nil, nil
+	}
+	return perms, err
+}
+
+// jsonWriter returns a function which writes v as indented JSON.
+func jsonWriter(v interface{}) func(io.Writer) error {
+	return func(w io.Writer) error {
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "\t")
+		return enc.Encode(v)
+	}
+}
+
+// writeFile creates the file name, writes its content using fn and
+// returns its hex encoded SHA-256 checksum.
+func writeFile(name string, fn func(io.Writer) error) (string, error) {
+	f, err := os.OpenFile(name, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0600)
+	if err != nil {
+		return "", fmt.Errorf("write file: %w", err)
+	}
+
+	h := sha256.New()
+	if err := fn(io.MultiWriter(f, h)); err != nil {
+		f.Close() //nolint: errcheck
+		return "", fmt.Errorf("write file %s: %w", name, err)
+	}
+
+	if err := f.Close(); err != nil {
+		return "", fmt.Errorf("write file: %w", err)
+	}
+
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// Prune removes the archives which aren't kept by the retention rules
+// and returns their directories.
+// Archives which can't be loaded are neither removed nor counted, and are
+// reported to the error handler.
+func (m *Manager) Prune() ([]string, error) {
+	archives, err := Archives(m.dir)
+	var invalid *InvalidArchiveError
+	if errors.As(err, &invalid) {
+		m.onError(err)
+	} else if err != nil {
+		return nil, err
+	}
+
+	var removed []string
+	cutoff := m.now().Add(-m.maxAge)
+	for i, a := range archives {
+		if i == 0 {
+			// Always keep the newest.
+			continue
+		}
+
+		if (m.keep > 0 && i >= m.keep) || (m.maxAge > 0 && a.Created.Before(cutoff)) {
+			if err := os.RemoveAll(a.Dir()); err != nil {
+				return removed, fmt.Errorf("prune: %w", err)
+			}
+			removed = append(removed, a.Dir())
+		}
+	}
+
+	return removed, nil
+}
+
+// Restore deploys the backup of the virtual server id from the archive dir
+// onto the virtual server target, or if target is 0 onto a new virtual
+// server with the name of the backed up server.
+// The checksums of the server's files are verified before anything is
+// changed. It returns the ID of the virtual server restored to.
+// The virtual server selected before the restore is reselected afterwards.
+func (m *Manager) Restore(ctx context.Context, dir string, id, target int) (int, error) {
+	manifest, err := LoadManifest(dir)
+	if err != nil {
+		return 0, err
+	}
+
+	b := manifest.Server(id)
+	if b == nil {
+		return 0, fmt.Errorf("restore: server %d not in archive %s", id, dir)
+	}
+
+	if err := manifest.verifyServer(b); err != nil {
+		return 0, err
+	}
+
+	snap, err := ts3.LoadSnapshot(filepath.Join(manifest.serverDir(id), SnapshotFile))
+	if err != nil {
+		return 0, err
+	}
+
+	err = m.withServer(ctx, func() error {
+		if target == 0 {
+			s, err := m.srv.CreateContext(ctx, b.Name)
+			if err != nil {
+				return err
+			}
+			target = s.ID
+		}
+
+		if err := m.srv.UseContext(ctx, target); err != nil {
+			return err
+		}
+
+		_, err := m.srv.SnapshotDeployContext(ctx, snap, &ts3.SnapshotDeployOptions{Password: m.password})
+		return err
+	})
+	if err != nil {
+		return 0, fmt.Errorf("restore server %d to %d: %w", id, target, err)
+	}
+
+	return target, nil
+}
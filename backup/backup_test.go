@@ -0,0 +1,336 @@
+package backup
+
+import (
+	"context"
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/multiplay/go-ts3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testSnapshot = `hash=abc virtualserver_name=Test|channel_id=1`
+
+// deployed is a snapshot deployed to fakeServer.
+type deployed struct {
+	id   int
+	snap string
+	opts *ts3.SnapshotDeployOptions
+}
+
+// fakeServer is a server with two online virtual servers and one offline.
+type fakeServer struct {
+	selected  int
+	used      []int
+	passwords []string
+	created   []string
+	deployed  []deployed
+	fail      bool
+}
+
+func (s *fakeServer) WhoamiContext(ctx context.Context) (*ts3.ConnectionInfo, error) {
+	return &ts3.ConnectionInfo{ServerID: s.selected}, nil
+}
+
+func (s *fakeServer) UseContext(ctx context.Context, id int) error {
+	s.selected = id
+	s.used = append(s.used, id)
+	return nil
+}
+
+func (s *fakeServer) ListContext(ctx context.Context, options ...string) ([]*ts3.Server, error) {
+	return []*ts3.Server{
+		{ID: 1, Status: "online"},
+		{ID: 2, Status: "offline"},
+		{ID: 3, Status: "online"},
+	}, nil
+}
+
+func (s *fakeServer) InfoContext(ctx context.Context) (*ts3.Server, error) {
+	return &ts3.Server{
+		ID:               s.selected,
+		Port:             9986 + s.selected,
+		Name:             "Server " + string(rune('0'+s.selected)),
+		UniqueIdentifier: "uid=",
+		Status:           "online",
+	}, nil
+}
+
+func (s *fakeServer) CreateContext(ctx context.Context, name string, args ...ts3.CmdArg) (*ts3.CreatedServer, error) {
+	s.created = append(s.created, name)
+	return &ts3.CreatedServer{ID: 9}, nil
+}
+
+func (s *fakeServer) SnapshotCreateContext(ctx context.Context, password string) (*ts3.Snapshot, error) {
+	if s.fail {
+		return nil, errors.New("snapshot failed")
+	}
+	s.passwords = append(s.passwords, password)
+	return ts3.ReadSnapshot(strings.NewReader(testSnapshot))
+}
+
+func (s *fakeServer) SnapshotDeployContext(ctx context.Context, snap *ts3.Snapshot, opts *ts3.SnapshotDeployOptions) (map[int]int, error) {
+	s.deployed = append(s.deployed, deployed{id: s.selected, snap: snap.String(), opts: opts})
+	return nil, nil
+}
+
+func (s *fakeServer) ChannelListContext(ctx context.Context, options ...string) ([]*ts3.Channel, error) {
+	return []*ts3.Channel{{ID: 1, ChannelName: "Lobby"}}, nil
+}
+
+func (s *fakeServer) GroupListContext(ctx context.Context) ([]*ts3.Group, error) {
+	return []*ts3.Group{{ID: 6, Name: "Admin"}, {ID: 8, Name: "Guest"}}, nil
+}
+
+func (s *fakeServer) ChannelGroupListContext(ctx context.Context) ([]*ts3.ChannelGroup, error) {
+	return []*ts3.ChannelGroup{{ID: 5, Name: "Channel Admin"}}, nil
+}
+
+func (s *fakeServer) ServerGroupPermListContext(ctx context.Context, id int) ([]*ts3.PermissionValue, error) {
+	if id == 8 {
+		return nil, &ts3.Error{ID: errEmptyResult, Msg: "database empty result set"}
+	}
+	return []*ts3.PermissionValue{{ID: 1, Name: "b_serverinstance_help_view", Value: 1}}, nil
+}
+
+func (s *fakeServer) ChannelGroupPermListContext(ctx context.Context, id int) ([]*ts3.PermissionValue, error) {
+	return []*ts3.PermissionValue{{ID: 2, Name: "b_channel_join_permanent", Value: 1}}, nil
+}
+
+func (s *fakeServer) ChannelPermListContext(ctx context.Context, id int) ([]*ts3.PermissionValue, error) {
+	return nil, &ts3.Error{ID: errEmptyResult, Msg: "database empty result set"}
+}
+
+func newTestManager(t *testing.T, srv *fakeServer, options ...func(*Manager) error) (*Manager, func()) {
+	t.Helper()
+	dir, err := ioutil.TempDir("", "ts3-backup")
+	require.NoError(t, err)
+
+	m, err := newManager(srv, dir, options...)
+	require.NoError(t, err)
+
+	return m, func() {
+		os.RemoveAll(dir) //nolint: errcheck
+	}
+}
+
+func TestCreate(t *testing.T) {
+	srv := &fakeServer{selected: 3}
+	m, cleanup := newTestManager(t, srv, Password("secret"))
+	defer cleanup()
+
+	created := time.Date(2026, 10, 16, 12, 30, 0, 0, time.UTC)
+	m.now = func() time.Time { return created }
+
+	manifest, err := m.Create(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, filepath.Join(m.dir, "20261016T123000Z"), manifest.Dir())
+	assert.Equal(t, FormatVersion, manifest.Version)
+	assert.True(t, created.Equal(manifest.Created))
+	assert.Equal(t, []int{1, 3, 3}, srv.used)
+	assert.Equal(t, 3, srv.selected)
+	assert.Equal(t, []string{"secret", "secret"}, srv.passwords)
+
+	if assert.Len(t, manifest.Servers, 2) {
+		s := manifest.Server(3)
+		require.NotNil(t, s)
+		assert.Equal(t, 9989, s.Port)
+		assert.Equal(t, "Server 3", s.Name)
+		assert.True(t, s.Encrypted)
+		assert.Len(t, s.Files, 6)
+		assert.Nil(t, manifest.Server(2))
+	}
+
+	loaded, err := LoadManifest(manifest.Dir())
+	require.NoError(t, err)
+	assert.Equal(t, manifest.Servers, loaded.Servers)
+	assert.NoError(t, loaded.Verify())
+
+	snap, err := ts3.LoadSnapshot(filepath.Join(manifest.Dir(), "1", SnapshotFile))
+	require.NoError(t, err)
+	assert.Equal(t, testSnapshot, snap.String())
+
+	perms, err := ioutil.ReadFile(filepath.Join(manifest.Dir(), "1", "permissions.json"))
+	require.NoError(t, err)
+	assert.Contains(t, string(perms), "b_channel_join_permanent")
+	assert.Contains(t, string(perms), `"8": null`)
+
+	// Tampering is detected.
+	name := filepath.Join(manifest.Dir(), "3", "channels.json")
+	require.NoError(t, ioutil.WriteFile(name, []byte("[]"), 0600))
+	assert.True(t, errors.Is(loaded.Verify(), ErrChecksum))
+}
+
+func TestCreateFailed(t *testing.T) {
+	srv := &fakeServer{fail: true}
+	m, cleanup := newTestManager(t, srv)
+	defer cleanup()
+
+	_, err := m.Create(context.Background())
+	assert.Error(t, err)
+
+	entries, err := ioutil.ReadDir(m.dir)
+	require.NoError(t, err)
+	assert.Empty(t, entries)
+}
+
+func TestPrune(t *testing.T) {
+	tests := map[string]struct {
+		keep     int
+		maxAge   time.Duration
+		expected int
+	}{
+		"keep-all": {expected: 5},
+		"keep":     {keep: 2, expected: 2},
+		"max-age":  {maxAge: 36 * time.Hour, expected: 2},
+		"both":     {keep: 3, maxAge: 72 * time.Hour, expected: 3},
+		"newest":   {maxAge: time.Minute, expected: 1},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			m, cleanup := newTestManager(t, &fakeServer{}, Retention(tc.keep, tc.maxAge))
+			defer cleanup()
+
+			now := time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)
+			for i := 4; i >= 0; i-- {
+				m.now = func() time.Time { return now.Add(-time.Duration(i) * 24 * time.Hour) }
+				_, err := m.Create(context.Background())
+				require.NoError(t, err)
+			}
+
+			archives, err := Archives(m.dir)
+			require.NoError(t, err)
+			assert.Len(t, archives, tc.expected)
+			assert.True(t, now.Equal(archives[0].Created))
+		})
+	}
+}
+
+func TestPruneInvalidArchive(t *testing.T) {
+	var errs []error
+	m, cleanup := newTestManager(t, &fakeServer{}, Retention(1, 0), ErrorHandler(func(err error) {
+		errs = append(errs, err)
+	}))
+	defer cleanup()
+
+	bad := filepath.Join(m.dir, "20200101T000000Z")
+	require.NoError(t, os.Mkdir(bad, 0700))
+	require.NoError(t, ioutil.WriteFile(filepath.Join(bad, ManifestFile), []byte("{"), 0600))
+
+	now := time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)
+	for i := 1; i >= 0; i-- {
+		m.now = func() time.Time { return now.Add(-time.Duration(i) * time.Hour) }
+		_, err := m.Create(context.Background())
+		require.NoError(t, err)
+	}
+
+	archives, err := Archives(m.dir)
+	var invalid *InvalidArchiveError
+	require.True(t, errors.As(err, &invalid))
+	assert.Len(t, invalid.Errs, 1)
+	assert.Len(t, archives, 1)
+	assert.Len(t, errs, 2)
+
+	_, err = os.Stat(bad)
+	assert.NoError(t, err)
+}
+
+func TestCreateInProgress(t *testing.T) {
+	m, cleanup := newTestManager(t, &fakeServer{})
+	defer cleanup()
+
+	created := time.Date(2026, 10, 16, 12, 30, 0, 0, time.UTC)
+	m.now = func() time.Time { return created }
+
+	// Another backup created in the same second is in progress.
+	tmp := filepath.Join(m.dir, ".20261016T123000Z.tmp")
+	require.NoError(t, os.Mkdir(tmp, 0700))
+	other := filepath.Join(tmp, "1")
+	require.NoError(t, os.Mkdir(other, 0700))
+
+	_, err := m.Create(context.Background())
+	assert.Error(t, err)
+
+	_, err = os.Stat(other)
+	assert.NoError(t, err)
+}
+
+func TestRestore(t *testing.T) {
+	srv := &fakeServer{selected: 1}
+	m, cleanup := newTestManager(t, srv, Password("secret"))
+	defer cleanup()
+
+	manifest, err := m.Create(context.Background())
+	require.NoError(t, err)
+
+	// Existing server.
+	srv.used = nil
+	id, err := m.Restore(context.Background(), manifest.Dir(), 3, 4)
+	require.NoError(t, err)
+	assert.Equal(t, 4, id)
+	assert.Equal(t, []int{4, 1}, srv.used)
+	expected := []deployed{{id: 4, snap: testSnapshot, opts: &ts3.SnapshotDeployOptions{Password: "secret"}}}
+	assert.Equal(t, expected, srv.deployed)
+
+	// New server.
+	id, err = m.Restore(context.Background(), manifest.Dir(), 3, 0)
+	require.NoError(t, err)
+	assert.Equal(t, 9, id)
+	assert.Equal(t, []string{"Server 3"}, srv.created)
+	assert.Equal(t, 9, srv.deployed[1].id)
+
+	// Unknown server.
+	_, err = m.Restore(context.Background(), manifest.Dir(), 2, 0)
+	assert.Error(t, err)
+
+	// Corrupt snapshot.
+	name := filepath.Join(manifest.Dir(), "3", SnapshotFile)
+	require.NoError(t, ioutil.WriteFile(name, []byte("x\n"), 0600))
+	_, err = m.Restore(context.Background(), manifest.Dir(), 3, 4)
+	assert.True(t, errors.Is(err, ErrChecksum))
+	assert.Len(t, srv.deployed, 2)
+}
+
+func TestRun(t *testing.T) {
+	srv := &fakeServer{fail: true}
+	errs := make(chan error, 10)
+	m, cleanup := newTestManager(t, srv, ErrorHandler(func(err error) {
+		errs <- err
+	}))
+	defer cleanup()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error)
+	go func() {
+		done <- m.Run(ctx, time.Hour)
+	}()
+
+	select {
+	case err := <-errs:
+		assert.Error(t, err)
+	case <-time.After(time.Second):
+		t.Fatal("no backup attempted")
+	}
+
+	cancel()
+	assert.Equal(t, context.Canceled, <-done)
+}
+
+func TestOptions(t *testing.T) {
+	_, err := newManager(&fakeServer{}, "", nil)
+	assert.Equal(t, ts3.ErrNilOption, err)
+
+	_, err = newManager(&fakeServer{}, "", Retention(-1, 0))
+	assert.Error(t, err)
+
+	m, err := newManager(&fakeServer{}, "")
+	require.NoError(t, err)
+	assert.Error(t, m.Run(context.Background(), 0))
+}
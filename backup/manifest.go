@@ -0,0 +1,191 @@
+package backup
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	// FormatVersion is the version of the archive format written by Create.
+	FormatVersion = 1
+
+	// ManifestFile is the name of the manifest file in an archive.
+	ManifestFile = "manifest.json"
+
+	// SnapshotFile is the name of the snapshot file of a server in an archive.
+	SnapshotFile = "snapshot.txt"
+)
+
+// ErrChecksum is returned when a file in an archive doesn't match its checksum.
+var ErrChecksum = errors.New("checksum mismatch")
+
+// InvalidArchiveError is returned by Archives, along with the valid
+// archives, when the manifests of some archives can't be loaded.
+type InvalidArchiveError struct {
+	// Errs contains the error for each invalid archive.
+	Errs []error
+}
+
+// Error implements error.
+func (e *InvalidArchiveError) Error() string {
+	msgs := make([]string, len(e.Errs))
+	for i, err := range e.Errs {
+		msgs[i] = err.Error()
+	}
+	return fmt.Sprintf("invalid archives: %s", strings.Join(msgs, "; "))
+}
+
+// Manifest describes an archive created by Create.
+type Manifest struct {
+	Version int             `json:"version"`
+	Created time.Time       `json:"created"`
+	Servers []*ServerBackup `json:"servers"`
+
+	dir string
+}
+
+// ServerBackup describes the backup of a virtual server in an archive.
+type ServerBackup struct {
+	ID               int    `json:"id"`
+	Port             int    `json:"port"`
+	Name             string `json:"name"`
+	UniqueIdentifier string `json:"unique_identifier"`
+	Encrypted        bool   `json:"encrypted"`
+
+	// Files maps the name of each file in the server's directory to its
+	// SHA-256 checksum.
+	Files map[string]string `json:"files"`
+}
+
+// Dir returns the directory of the archive.
+func (m *Manifest) Dir() string {
+	return m.dir
+}
+
+// Server returns the backup of the virtual server id or nil if the archive
+// doesn't contain it.
+func (m *Manifest) Server(id int) *ServerBackup {
+	for _, s := range m.Servers {
+		if s.ID == id {
+			return s
+		}
+	}
+	return nil
+}
+
+// serverDir returns the directory of the backup of the virtual server id.
+func (m *Manifest) serverDir(id int) string {
+	return filepath.Join(m.dir, strconv.Itoa(id))
+}
+
+// Verify checks the files of all the servers in the archive match their
+// checksums.
+func (m *Manifest) Verify() error {
+	for _, s := range m.Servers {
+		if err := m.verifyServer(s); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// verifyServer checks the files of s match their checksums.
+func (m *Manifest) verifyServer(s *ServerBackup) error {
+	dir := m.serverDir(s.ID)
+	for name, sum := range s.Files {
+		got, err := checksum(filepath.Join(dir, name))
+		if err != nil {
+			return err
+		}
+		if got != sum {
+			return fmt.Errorf("verify %s: %w", filepath.Join(dir, name), ErrChecksum)
+		}
+	}
+	return nil
+}
+
+// checksum returns the hex encoded SHA-256 checksum of the file name.
+func checksum(name string) (string, error) {
+	f, err := os.Open(name)
+	if err != nil {
+		return "", fmt.Errorf("checksum: %w", err)
+	}
+	defer f.Close() //nolint: errcheck
+
+	h := sha256.New()
+	if _, err := io.Copy(h, f); err != nil {
+		return "", fmt.Errorf("checksum: %w", err)
+	}
+
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// LoadManifest reads the manifest of the archive dir.
+func LoadManifest(dir string) (*Manifest, error) {
+	data, err := ioutil.ReadFile(filepath.Join(dir, ManifestFile))
+	if err != nil {
+		return nil, fmt.Errorf("load manifest: %w", err)
+	}
+
+	m := &Manifest{dir: dir}
+	if err := json.Unmarshal(data, m); err != nil {
+		return nil, fmt.Errorf("load manifest: %w", err)
+	}
+
+	if m.Version > FormatVersion {
+		return nil, fmt.Errorf("load manifest: unsupported version %d", m.Version)
+	}
+
+	return m, nil
+}
+
+// Archives returns the manifests of the archives in dir, newest first.
+// Hidden directories, which includes archives being created, and
+// directories without a manifest are ignored.
+// Archives whose manifest can't be loaded are skipped and reported by
+// returning an *InvalidArchiveError along with the valid archives.
+func Archives(dir string) ([]*Manifest, error) {
+	entries, err := ioutil.ReadDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("archives: %w", err)
+	}
+
+	var archives []*Manifest
+	var invalid []error
+	for _, e := range entries {
+		if !e.IsDir() || strings.HasPrefix(e.Name(), ".") {
+			continue
+		}
+
+		name := filepath.Join(dir, e.Name())
+		m, err := LoadManifest(name)
+		if errors.Is(err, os.ErrNotExist) {
+			continue
+		} else if err != nil {
+			invalid = append(invalid, fmt.Errorf("%s: %w", name, err))
+			continue
+		}
+		archives = append(archives, m)
+	}
+
+	sort.Slice(archives, func(i, j int) bool {
+		return archives[i].Created.After(archives[j].Created)
+	})
+
+	if len(invalid) > 0 {
+		return archives, &InvalidArchiveError{Errs: invalid}
+	}
+
+	return archives, nil
+}
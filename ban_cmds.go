@@ -0,0 +1,180 @@
+package ts3
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// Ban represents a ban on a virtual server.
+type Ban struct {
+	ID                int       `ms:"banid"`
+	IP                string    `ms:"ip"`
+	Name              string    `ms:"name"`
+	UniqueIdentifier  string    `ms:"uid"`
+	MyTSID            string    `ms:"mytsid"`
+	LastNickname      string    `ms:"lastnickname"`
+	Created           time.Time `ms:"created"`
+	Duration          int       `ms:"duration"` // In seconds, 0 is permanent.
+	InvokerName       string    `ms:"invokername"`
+	InvokerDatabaseID int       `ms:"invokercldbid"`
+	InvokerUID        string    `ms:"invokeruid"`
+	Reason            string    `ms:"reason"`
+	Enforcements      int       `ms:"enforcements"`
+}
+
+// BanRule describes the clients matched by a ban.
+// At least one of IP, Name, UniqueIdentifier or MyTSID must be set.
+type BanRule struct {
+	IP               string        // Regular expression matching the client IP.
+	Name             string        // Regular expression matching the client nickname.
+	UniqueIdentifier string        // Unique identifier of the client.
+	MyTSID           string        // myTeamSpeak ID of the client.
+	Duration         time.Duration // Length of the ban in whole seconds, exactly 0 is permanent.
+	Reason           string
+}
+
+// args returns the command arguments for r.
+func (r *BanRule) args() ([]CmdArg, error) {
+	var args []CmdArg
+	for _, a := range []struct {
+		key, val string
+	}{
+		{"ip", r.IP},
+		{"name", r.Name},
+		{"uid", r.UniqueIdentifier},
+		{"mytsid", r.MyTSID},
+	} {
+		if a.val != "" {
+			args = append(args, NewArg(a.key, a.val))
+		}
+	}
+
+	if len(args) == 0 {
+		return nil, ErrEmptyBanRule
+	}
+
+	ban, err := banArgs(r.Duration, r.Reason)
+	if err != nil {
+		return nil, err
+	}
+
+	return append(args, ban...), nil
+}
+
+// banArgs returns the time and banreason arguments, omitting those not set.
+// Only a duration of exactly zero is permanent, so negative durations and
+// those under a second, which can't be sent, are rejected.
+func banArgs(duration time.Duration, reason string) ([]CmdArg, error) {
+	if duration < 0 || (duration > 0 && duration < time.Second) {
+		return nil, fmt.Errorf("ban: invalid duration %v", duration)
+	}
+
+	var args []CmdArg
+	if duration > 0 {
+		args = append(args, NewArg("time", int(duration/time.Second)))
+	}
+	if reason != "" {
+		args = append(args, NewArg("banreason", reason))
+	}
+	return args, nil
+}
+
+// BanList returns the active bans on the selected server.
+func (s *ServerMethods) BanList() ([]*Ban, error) {
+	return s.BanListContext(context.Background())
+}
+
+// BanListContext returns the active bans on the selected server.
+func (s *ServerMethods) BanListContext(ctx context.Context) ([]*Ban, error) {
+	var bans []*Ban
+	if _, err := s.ExecCmdContext(ctx, NewCmd("banlist").WithResponse(&bans)); err != nil {
+		if isEmptyResult(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return bans, nil
+}
+
+// BanAdd adds a ban matching rule and returns its ID.
+func (s *ServerMethods) BanAdd(rule BanRule) (int, error) {
+	return s.BanAddContext(context.Background(), rule)
+}
+
+// BanAddContext adds a ban matching rule and returns its ID.
+func (s *ServerMethods) BanAddContext(ctx context.Context, rule BanRule) (int, error) {
+	args, err := rule.args()
+	if err != nil {
+		return 0, err
+	}
+
+	r := struct {
+		ID int `ms:"banid"`
+	}{}
+	if _, err := s.ExecCmdContext(ctx, NewCmd("banadd").WithArgs(args...).WithResponse(&r)); err != nil {
+		return 0, err
+	}
+
+	return r.ID, nil
+}
+
+// BanClient bans the online client id for duration, in whole seconds with
+// exactly 0 being permanent, and kicks it from the server. It returns the IDs of the bans created,
+// typically one each for the client's IP and unique identifier.
+func (s *ServerMethods) BanClient(id int, duration time.Duration, reason string) ([]int, error) {
+	return s.BanClientContext(context.Background(), id, duration, reason)
+}
+
+// BanClientContext bans the online client id for duration and kicks it from the server.
+func (s *ServerMethods) BanClientContext(ctx context.Context, id int, duration time.Duration, reason string) ([]int, error) {
+	ban, err := banArgs(duration, reason)
+	if err != nil {
+		return nil, err
+	}
+
+	args := append([]CmdArg{NewArg("clid", id)}, ban...)
+	lines, err := s.ExecCmdContext(ctx, NewCmd("banclient").WithArgs(args...))
+	if err != nil {
+		return nil, err
+	}
+
+	// Each ban is returned on its own line.
+	var ids []int
+	for _, l := range lines {
+		var bans []*struct {
+			ID int `ms:"banid"`
+		}
+		if err := DecodeResponse([]string{l}, &bans); err != nil {
+			return nil, err
+		}
+		for _, b := range bans {
+			ids = append(ids, b.ID)
+		}
+	}
+
+	return ids, nil
+}
+
+// BanDel deletes the ban id.
+func (s *ServerMethods) BanDel(id int) error {
+	return s.BanDelContext(context.Background(), id)
+}
+
+// BanDelContext deletes the ban id.
+func (s *ServerMethods) BanDelContext(ctx context.Context, id int) error {
+	_, err := s.ExecCmdContext(ctx, NewCmd("bandel").WithArgs(NewArg("banid", id)))
+	return err
+}
+
+// BanDelAll deletes all the bans on the selected server.
+func (s *ServerMethods) BanDelAll() error {
+	return s.BanDelAllContext(context.Background())
+}
+
+// BanDelAllContext deletes all the bans on the selected server.
+func (s *ServerMethods) BanDelAllContext(ctx context.Context) error {
+	_, err := s.ExecCmdContext(ctx, NewCmd("bandelall"))
+	return err
+}
@@ -0,0 +1,152 @@
+package ts3
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCmdsBan(t *testing.T) {
+	s := newServer(t)
+	defer func() {
+		assert.NoError(t, s.Close())
+	}()
+
+	c, err := NewClient(s.Addr, Timeout(time.Second*2))
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	defer func() {
+		assert.NoError(t, c.Close())
+	}()
+
+	testCmdsBan(t, c, s)
+}
+
+func TestCmdsBanSSH(t *testing.T) {
+	s := newServer(t, useSSH())
+	defer func() {
+		assert.NoError(t, s.Close())
+	}()
+
+	c, err := NewClient(s.Addr, Timeout(time.Second*2), SSH(sshClientTestConfig))
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	defer func() {
+		assert.NoError(t, c.Close())
+	}()
+
+	testCmdsBan(t, c, s)
+}
+
+func testCmdsBan(t *testing.T, c *Client, s *server) {
+	t.Helper()
+	lastCmd := func() string {
+		received := s.Received()
+		return received[len(received)-1]
+	}
+
+	banlist := func(t *testing.T) {
+		t.Helper()
+		bans, err := c.Server.BanList()
+		if !assert.NoError(t, err) {
+			return
+		}
+
+		expected := []*Ban{
+			{
+				ID:                5,
+				IP:                "1.2.3.4",
+				LastNickname:      "Bad",
+				Created:           time.Unix(1259147468, 0),
+				Duration:          3600,
+				InvokerName:       "admin",
+				InvokerDatabaseID: 1,
+				InvokerUID:        "xyz=",
+				Reason:            "spam",
+				Enforcements:      2,
+			},
+			{
+				ID:                6,
+				Name:              "Bad.*",
+				Created:           time.Unix(1259147468, 0),
+				InvokerName:       "admin",
+				InvokerDatabaseID: 1,
+				InvokerUID:        "xyz=",
+			},
+		}
+		assert.Equal(t, expected, bans)
+	}
+
+	banadd := func(t *testing.T) {
+		t.Helper()
+		id, err := c.Server.BanAdd(BanRule{
+			IP:       `1\.2\.3\..*`,
+			Name:     "Bad",
+			Duration: time.Hour,
+			Reason:   "go away",
+		})
+		if !assert.NoError(t, err) {
+			return
+		}
+		assert.Equal(t, 7, id)
+		assert.Equal(t, `banadd ip=1\\.2\\.3\\..* name=Bad time=3600 banreason=go\saway`, lastCmd())
+
+		_, err = c.Server.BanAdd(BanRule{UniqueIdentifier: "xyz="})
+		assert.NoError(t, err)
+		assert.Equal(t, "banadd uid=xyz=", lastCmd())
+
+		_, err = c.Server.BanAdd(BanRule{Duration: time.Hour})
+		assert.Equal(t, ErrEmptyBanRule, err)
+
+		_, err = c.Server.BanAdd(BanRule{UniqueIdentifier: "xyz=", Duration: time.Millisecond * 500})
+		assert.Error(t, err)
+
+		_, err = c.Server.BanAdd(BanRule{UniqueIdentifier: "xyz=", Duration: -time.Hour})
+		assert.Error(t, err)
+	}
+
+	banclient := func(t *testing.T) {
+		t.Helper()
+		ids, err := c.Server.BanClient(3, time.Minute, "")
+		if !assert.NoError(t, err) {
+			return
+		}
+		assert.Equal(t, []int{8, 9}, ids)
+		assert.Equal(t, "banclient clid=3 time=60", lastCmd())
+
+		_, err = c.Server.BanClient(3, time.Millisecond, "")
+		assert.Error(t, err)
+	}
+
+	bandel := func(t *testing.T) {
+		t.Helper()
+		assert.NoError(t, c.Server.BanDel(5))
+		assert.Equal(t, "bandel banid=5", lastCmd())
+	}
+
+	bandelall := func(t *testing.T) {
+		t.Helper()
+		assert.NoError(t, c.Server.BanDelAll())
+		assert.Equal(t, "bandelall", lastCmd())
+	}
+
+	tests := []struct {
+		name string
+		f    func(t *testing.T)
+	}{
+		{"banlist", banlist},
+		{"banadd", banadd},
+		{"banclient", banclient},
+		{"bandel", bandel},
+		{"bandelall", bandelall},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, tc.f)
+	}
+}
@@ -1,18 +1,41 @@
 package ts3
 
+import (
+	"context"
+)
+
 // Login authenticates with the server.
 func (c *Client) Login(user, passwd string) error {
-	_, err := c.ExecCmd(NewCmd("login").WithArgs(
+	return c.LoginContext(context.Background(), user, passwd)
+}
+
+// LoginContext authenticates with the server.
+func (c *Client) LoginContext(ctx context.Context, user, passwd string) error {
+	cmd := NewCmd("login").WithArgs(
 		NewArg("client_login_name", user),
-		NewArg("client_login_password", passwd)),
+		NewArg("client_login_password", passwd),
 	)
-	return err
+	if _, err := c.ExecCmdContext(ctx, cmd); err != nil {
+		return err
+	}
+
+	c.state.setLogin(cmd)
+	return nil
 }
 
 // Logout deselect virtual server and log out.
 func (c *Client) Logout() error {
-	_, err := c.Exec("logout")
-	return err
+	return c.LogoutContext(context.Background())
+}
+
+// LogoutContext deselect virtual server and log out.
+func (c *Client) LogoutContext(ctx context.Context) error {
+	if _, err := c.ExecContext(ctx, "logout"); err != nil {
+		return err
+	}
+
+	c.state.setLogout()
+	return nil
 }
 
 // Version represents version information.
@@ -24,8 +47,13 @@ type Version struct {
 
 // Version returns version information.
 func (c *Client) Version() (*Version, error) {
+	return c.VersionContext(context.Background())
+}
+
+// VersionContext returns version information.
+func (c *Client) VersionContext(ctx context.Context) (*Version, error) {
 	v := &Version{}
-	if _, err := c.ExecCmd(NewCmd("version").WithResponse(v)); err != nil {
+	if _, err := c.ExecCmdContext(ctx, NewCmd("version").WithResponse(v)); err != nil {
 		return nil, err
 	}
 
@@ -34,14 +62,36 @@ func (c *Client) Version() (*Version, error) {
 
 // Use selects a virtual server by id.
 func (c *Client) Use(id int) error {
-	_, err := c.ExecCmd(NewCmd("use").WithArgs(NewArg("sid", id)))
-	return err
+	return c.UseContext(context.Background(), id)
+}
+
+// UseContext selects a virtual server by id.
+func (c *Client) UseContext(ctx context.Context, id int) error {
+	cmd := NewCmd("use").WithArgs(NewArg("sid", id))
+	if _, err := c.ExecCmdContext(ctx, cmd); err != nil {
+		return err
+	}
+
+	c.state.setUse(cmd)
+	c.ids.reset()
+	return nil
 }
 
 // UsePort selects a virtual server by port.
 func (c *Client) UsePort(port int) error {
-	_, err := c.ExecCmd(NewCmd("use").WithArgs(NewArg("port", port)))
-	return err
+	return c.UsePortContext(context.Background(), port)
+}
+
+// UsePortContext selects a virtual server by port.
+func (c *Client) UsePortContext(ctx context.Context, port int) error {
+	cmd := NewCmd("use").WithArgs(NewArg("port", port))
+	if _, err := c.ExecCmdContext(ctx, cmd); err != nil {
+		return err
+	}
+
+	c.state.setUse(cmd)
+	c.ids.reset()
+	return nil
 }
 
 // ConnectionInfo represents an answer of the whoami command.
@@ -61,8 +111,13 @@ type ConnectionInfo struct {
 
 // Whoami returns information about the current connection including the currently selected virtual server.
 func (c *Client) Whoami() (*ConnectionInfo, error) {
+	return c.WhoamiContext(context.Background())
+}
+
+// WhoamiContext returns information about the current connection including the currently selected virtual server.
+func (c *Client) WhoamiContext(ctx context.Context) (*ConnectionInfo, error) {
 	i := &ConnectionInfo{}
-	if _, err := c.ExecCmd(NewCmd("whoami").WithResponse(&i)); err != nil {
+	if _, err := c.ExecCmdContext(ctx, NewCmd("whoami").WithResponse(&i)); err != nil {
 		return nil, err
 	}
 
@@ -80,31 +135,71 @@ const (
 
 // ClientUpdate changes properties of the client to a given value.
 func (c *Client) ClientUpdate(properties ...CmdArg) error {
-	_, err := c.ExecCmd(NewCmd("clientupdate").WithArgs(properties...))
-	return err
+	return c.ClientUpdateContext(context.Background(), properties...)
+}
+
+// ClientUpdateContext changes properties of the client to a given value.
+func (c *Client) ClientUpdateContext(ctx context.Context, properties ...CmdArg) error {
+	if _, err := c.ExecCmdContext(ctx, NewCmd("clientupdate").WithArgs(properties...)); err != nil {
+		return err
+	}
+
+	// Record nickname changes so they're restored on reconnect.
+	for _, p := range properties {
+		if a, ok := p.(*Arg); ok && a.key == ClientNickname {
+			c.state.setNick(NewCmd("clientupdate").WithArgs(a))
+		}
+	}
+
+	return nil
 }
 
 // SetNick sets the clients nickname.
 func (c *Client) SetNick(nick string) error {
-	return c.ClientUpdate(NewArg(ClientNickname, nick))
+	return c.SetNickContext(context.Background(), nick)
+}
+
+// SetNickContext sets the clients nickname.
+func (c *Client) SetNickContext(ctx context.Context, nick string) error {
+	return c.ClientUpdateContext(ctx, NewArg(ClientNickname, nick))
 }
 
 // SetTalker sets whether the client is able to talk.
 func (c *Client) SetTalker(val bool) error {
-	return c.ClientUpdate(NewArg(ClientIsTalker, val))
+	return c.SetTalkerContext(context.Background(), val)
+}
+
+// SetTalkerContext sets whether the client is able to talk.
+func (c *Client) SetTalkerContext(ctx context.Context, val bool) error {
+	return c.ClientUpdateContext(ctx, NewArg(ClientIsTalker, val))
 }
 
 // SetDescription sets the clients description.
 func (c *Client) SetDescription(description string) error {
-	return c.ClientUpdate(NewArg(ClientDescription, description))
+	return c.SetDescriptionContext(context.Background(), description)
+}
+
+// SetDescriptionContext sets the clients description.
+func (c *Client) SetDescriptionContext(ctx context.Context, description string) error {
+	return c.ClientUpdateContext(ctx, NewArg(ClientDescription, description))
 }
 
 // SetChannelCommander sets whether the client is a channel commander.
 func (c *Client) SetChannelCommander(val bool) error {
-	return c.ClientUpdate(NewArg(ClientIsChannelCommander, val))
+	return c.SetChannelCommanderContext(context.Background(), val)
+}
+
+// SetChannelCommanderContext sets whether the client is a channel commander.
+func (c *Client) SetChannelCommanderContext(ctx context.Context, val bool) error {
+	return c.ClientUpdateContext(ctx, NewArg(ClientIsChannelCommander, val))
 }
 
 // SetIcon sets the clients icon based on the CRC32 checksum.
 func (c *Client) SetIcon(id int) error {
-	return c.ClientUpdate(NewArg(ClientIconID, id))
+	return c.SetIconContext(context.Background(), id)
+}
+
+// SetIconContext sets the clients icon based on the CRC32 checksum.
+func (c *Client) SetIconContext(ctx context.Context, id int) error {
+	return c.ClientUpdateContext(ctx, NewArg(ClientIconID, id))
 }
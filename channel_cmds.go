@@ -0,0 +1,335 @@
+package ts3
+
+import (
+	"context"
+)
+
+const (
+	// ChannelListTopic can be passed to ChannelList to retrieve channel topic information.
+	ChannelListTopic = "-topic"
+	// ChannelListFlags can be passed to ChannelList to retrieve channel flag information.
+	ChannelListFlags = "-flags"
+	// ChannelListVoice can be passed to ChannelList to retrieve channel voice information.
+	ChannelListVoice = "-voice"
+	// ChannelListLimits can be passed to ChannelList to retrieve channel limit information.
+	ChannelListLimits = "-limits"
+	// ChannelListIcon can be passed to ChannelList to retrieve channel icon information.
+	ChannelListIcon = "-icon"
+	// ChannelListSecondsEmpty can be passed to ChannelList to retrieve how long channels have been empty.
+	ChannelListSecondsEmpty = "-secondsempty"
+	// ChannelListFull can be passed to ChannelList to get all extended channel information.
+	ChannelListFull = "-topic -flags -voice -limits -icon -secondsempty"
+)
+
+// ChannelLifetime determines when a channel is deleted.
+// It can be passed to ChannelCreate and ChannelEdit.
+type ChannelLifetime int
+
+const (
+	// ChannelTemporary channels are deleted once the last client leaves.
+	ChannelTemporary ChannelLifetime = iota
+	// ChannelSemiPermanent channels are deleted when the server restarts.
+	ChannelSemiPermanent
+	// ChannelPermanent channels are never automatically deleted.
+	ChannelPermanent
+)
+
+// ArgString implements CmdArg.
+func (l ChannelLifetime) ArgString() string {
+	return NewArgSet(
+		NewArg("channel_flag_permanent", l == ChannelPermanent),
+		NewArg("channel_flag_semi_permanent", l == ChannelSemiPermanent),
+	).ArgString()
+}
+
+// ChannelCodec is the voice codec used by a channel.
+// It can be passed to ChannelCreate and ChannelEdit.
+type ChannelCodec int
+
+// Channel codecs.
+const (
+	ChannelCodecSpeexNarrowband    ChannelCodec = 0
+	ChannelCodecSpeexWideband      ChannelCodec = 1
+	ChannelCodecSpeexUltraWideband ChannelCodec = 2
+	ChannelCodecCELTMono           ChannelCodec = 3
+	ChannelCodecOpusVoice          ChannelCodec = 4
+	ChannelCodecOpusMusic          ChannelCodec = 5
+)
+
+// ArgString implements CmdArg.
+func (c ChannelCodec) ArgString() string {
+	return NewArg("channel_codec", int(c)).ArgString()
+}
+
+// ChannelCodecQuality is the quality, 0-10, of a channel's voice codec.
+// It can be passed to ChannelCreate and ChannelEdit.
+type ChannelCodecQuality int
+
+// ArgString implements CmdArg.
+func (q ChannelCodecQuality) ArgString() string {
+	return NewArg("channel_codec_quality", int(q)).ArgString()
+}
+
+// ChannelMaxClients is the maximum number of clients allowed in a channel,
+// a negative value means unlimited.
+// It can be passed to ChannelCreate and ChannelEdit.
+type ChannelMaxClients int
+
+// ArgString implements CmdArg.
+func (m ChannelMaxClients) ArgString() string {
+	if m < 0 {
+		return NewArgSet(
+			NewArg("channel_maxclients", -1),
+			NewArg("channel_flag_maxclients_unlimited", true),
+		).ArgString()
+	}
+
+	return NewArgSet(
+		NewArg("channel_maxclients", int(m)),
+		NewArg("channel_flag_maxclients_unlimited", false),
+	).ArgString()
+}
+
+// ChannelPassword is the password required to join a channel.
+// It can be passed to ChannelCreate and ChannelEdit.
+type ChannelPassword string
+
+// ArgString implements CmdArg.
+func (p ChannelPassword) ArgString() string {
+	return NewArg("channel_password", string(p)).ArgString()
+}
+
+// ChannelTopic is the topic of a channel.
+// It can be passed to ChannelCreate and ChannelEdit.
+type ChannelTopic string
+
+// ArgString implements CmdArg.
+func (t ChannelTopic) ArgString() string {
+	return NewArg("channel_topic", string(t)).ArgString()
+}
+
+// ChannelDescription is the description of a channel.
+// It can be passed to ChannelCreate and ChannelEdit.
+type ChannelDescription string
+
+// ArgString implements CmdArg.
+func (d ChannelDescription) ArgString() string {
+	return NewArg("channel_description", string(d)).ArgString()
+}
+
+// ChannelParent is the ID of a channel's parent channel.
+// It can be passed to ChannelCreate.
+type ChannelParent int
+
+// ArgString implements CmdArg.
+func (p ChannelParent) ArgString() string {
+	return NewArg("cpid", int(p)).ArgString()
+}
+
+// Channel represents a TeamSpeak 3 channel in a virtual server.
+type Channel struct {
+	// Following variables are always returned by ChannelList().
+	ID                   int    `ms:"cid"`
+	ParentID             int    `ms:"pid"`
+	ChannelOrder         int    `ms:"channel_order"`
+	ChannelName          string `ms:"channel_name"`
+	TotalClients         int    `ms:"total_clients"`
+	NeededSubscribePower int    `ms:"channel_needed_subscribe_power"`
+	// Following variables are optional and can be requested in ChannelList() to get extended channel information.
+	*ChannelExt `ms:",squash"` // Only populated if any of the options is passed to ChannelList.
+}
+
+// ChannelExt represents all ChannelList extensions.
+type ChannelExt struct {
+	Topic          *string        `ms:"channel_topic"` // Only populated if ChannelListTopic or ChannelListFull is passed to ChannelList.
+	*ChannelFlags  `ms:",squash"` // Only populated if ChannelListFlags or ChannelListFull is passed to ChannelList.
+	*ChannelVoice  `ms:",squash"` // Only populated if ChannelListVoice or ChannelListFull is passed to ChannelList.
+	*ChannelLimits `ms:",squash"` // Only populated if ChannelListLimits or ChannelListFull is passed to ChannelList.
+	IconID         *int           `ms:"channel_icon_id"` // Only populated if ChannelListIcon or ChannelListFull is passed to ChannelList.
+	SecondsEmpty   *int           `ms:"seconds_empty"`   // Only populated if ChannelListSecondsEmpty or ChannelListFull is passed to ChannelList.
+}
+
+// ChannelFlags represents all ChannelList extensions when the ChannelListFlags parameter is passed.
+type ChannelFlags struct {
+	FlagDefault       *bool `ms:"channel_flag_default"`
+	FlagPassword      *bool `ms:"channel_flag_password"`
+	FlagPermanent     *bool `ms:"channel_flag_permanent"`
+	FlagSemiPermanent *bool `ms:"channel_flag_semi_permanent"`
+}
+
+// ChannelVoice represents all ChannelList extensions when the ChannelListVoice parameter is passed.
+type ChannelVoice struct {
+	Codec           *ChannelCodec `ms:"channel_codec"`
+	CodecQuality    *int          `ms:"channel_codec_quality"`
+	NeededTalkPower *int          `ms:"channel_needed_talk_power"`
+}
+
+// ChannelLimits represents all ChannelList extensions when the ChannelListLimits parameter is passed.
+type ChannelLimits struct {
+	TotalClientsFamily *int `ms:"total_clients_family"`
+	MaxClients         *int `ms:"channel_maxclients"`
+	MaxFamilyClients   *int `ms:"channel_maxfamilyclients"`
+}
+
+// ChannelList returns a list of channels for the selected server.
+func (s *ServerMethods) ChannelList(options ...string) ([]*Channel, error) {
+	return s.ChannelListContext(context.Background(), options...)
+}
+
+// ChannelListContext returns a list of channels for the selected server.
+func (s *ServerMethods) ChannelListContext(ctx context.Context, options ...string) ([]*Channel, error) {
+	var channels []*Channel
+	if _, err := s.ExecCmdContext(ctx, NewCmd("channellist").WithOptions(options...).WithResponse(&channels)); err != nil {
+		return nil, err
+	}
+
+	return channels, nil
+}
+
+// ChannelInfo represents detailed information about a channel.
+type ChannelInfo struct {
+	ParentID                      int          `ms:"pid"`
+	Name                          string       `ms:"channel_name"`
+	Topic                         string       `ms:"channel_topic"`
+	Description                   string       `ms:"channel_description"`
+	Password                      string       `ms:"channel_password"`
+	Codec                         ChannelCodec `ms:"channel_codec"`
+	CodecQuality                  int          `ms:"channel_codec_quality"`
+	MaxClients                    int          `ms:"channel_maxclients"`
+	MaxFamilyClients              int          `ms:"channel_maxfamilyclients"`
+	Order                         int          `ms:"channel_order"`
+	FlagPermanent                 bool         `ms:"channel_flag_permanent"`
+	FlagSemiPermanent             bool         `ms:"channel_flag_semi_permanent"`
+	FlagDefault                   bool         `ms:"channel_flag_default"`
+	FlagPassword                  bool         `ms:"channel_flag_password"`
+	CodecLatencyFactor            int          `ms:"channel_codec_latency_factor"`
+	CodecIsUnencrypted            bool         `ms:"channel_codec_is_unencrypted"`
+	SecuritySalt                  string       `ms:"channel_security_salt"`
+	DeleteDelay                   int          `ms:"channel_delete_delay"`
+	FlagMaxClientsUnlimited       bool         `ms:"channel_flag_maxclients_unlimited"`
+	FlagMaxFamilyClientsUnlimited bool         `ms:"channel_flag_maxfamilyclients_unlimited"`
+	FlagMaxFamilyClientsInherited bool         `ms:"channel_flag_maxfamilyclients_inherited"`
+	FilePath                      string       `ms:"channel_filepath"`
+	NeededTalkPower               int          `ms:"channel_needed_talk_power"`
+	ForcedSilence                 bool         `ms:"channel_forced_silence"`
+	NamePhonetic                  string       `ms:"channel_name_phonetic"`
+	IconID                        int          `ms:"channel_icon_id"`
+	BannerGFXURL                  string       `ms:"channel_banner_gfx_url"`
+	BannerMode                    int          `ms:"channel_banner_mode"`
+	SecondsEmpty                  int          `ms:"seconds_empty"`
+}
+
+// Lifetime returns the lifetime of the channel.
+func (c *ChannelInfo) Lifetime() ChannelLifetime {
+	switch {
+	case c.FlagPermanent:
+		return ChannelPermanent
+	case c.FlagSemiPermanent:
+		return ChannelSemiPermanent
+	default:
+		return ChannelTemporary
+	}
+}
+
+// ChannelInfo returns detailed information about the channel specified by id.
+func (s *ServerMethods) ChannelInfo(id int) (*ChannelInfo, error) {
+	return s.ChannelInfoContext(context.Background(), id)
+}
+
+// ChannelInfoContext returns detailed information about the channel specified by id.
+func (s *ServerMethods) ChannelInfoContext(ctx context.Context, id int) (*ChannelInfo, error) {
+	r := &ChannelInfo{}
+	if _, err := s.ExecCmdContext(ctx, NewCmd("channelinfo").WithArgs(NewArg("cid", id)).WithResponse(r)); err != nil {
+		return nil, err
+	}
+
+	return r, nil
+}
+
+// ChannelCreate creates a new channel with the given properties and returns its ID.
+// Typed properties such as ChannelPermanent, ChannelCodecOpusVoice, ChannelMaxClients,
+// ChannelPassword, ChannelTopic and ChannelDescription can be mixed with any other
+// CmdArg e.g. NewArg("channel_needed_talk_power", 10).
+// Channels are temporary unless otherwise specified.
+func (s *ServerMethods) ChannelCreate(name string, props ...CmdArg) (int, error) {
+	return s.ChannelCreateContext(context.Background(), name, props...)
+}
+
+// ChannelCreateContext creates a new channel with the given properties and returns its ID.
+func (s *ServerMethods) ChannelCreateContext(ctx context.Context, name string, props ...CmdArg) (int, error) {
+	r := struct {
+		ID int `ms:"cid"`
+	}{}
+	args := append([]CmdArg{NewArg("channel_name", name)}, props...)
+	if _, err := s.ExecCmdContext(ctx, NewCmd("channelcreate").WithArgs(args...).WithResponse(&r)); err != nil {
+		return 0, err
+	}
+
+	return r.ID, nil
+}
+
+// ChannelEdit changes the properties of the channel specified by id.
+// It accepts the same properties as ChannelCreate.
+func (s *ServerMethods) ChannelEdit(id int, props ...CmdArg) error {
+	return s.ChannelEditContext(context.Background(), id, props...)
+}
+
+// ChannelEditContext changes the properties of the channel specified by id.
+func (s *ServerMethods) ChannelEditContext(ctx context.Context, id int, props ...CmdArg) error {
+	args := append([]CmdArg{NewArg("cid", id)}, props...)
+	_, err := s.ExecCmdContext(ctx, NewCmd("channeledit").WithArgs(args...))
+	return err
+}
+
+// ChannelDelete deletes the channel specified by id.
+// If force is true the channel is deleted even if there are clients in it,
+// which are moved to the default channel.
+func (s *ServerMethods) ChannelDelete(id int, force bool) error {
+	return s.ChannelDeleteContext(context.Background(), id, force)
+}
+
+// ChannelDeleteContext deletes the channel specified by id.
+func (s *ServerMethods) ChannelDeleteContext(ctx context.Context, id int, force bool) error {
+	_, err := s.ExecCmdContext(ctx, NewCmd("channeldelete").WithArgs(
+		NewArg("cid", id),
+		NewArg("force", force),
+	))
+	return err
+}
+
+// ChannelMove moves the channel specified by id to become a sub channel of parentID,
+// sorted after the channel order, which is the ID of the channel above it or 0 for
+// the first position.
+func (s *ServerMethods) ChannelMove(id, parentID, order int) error {
+	return s.ChannelMoveContext(context.Background(), id, parentID, order)
+}
+
+// ChannelMoveContext moves the channel specified by id to become a sub channel of parentID.
+func (s *ServerMethods) ChannelMoveContext(ctx context.Context, id, parentID, order int) error {
+	_, err := s.ExecCmdContext(ctx, NewCmd("channelmove").WithArgs(
+		NewArg("cid", id),
+		NewArg("cpid", parentID),
+		NewArg("order", order),
+	))
+	return err
+}
+
+// ChannelFind returns the channels whose name matches pattern, which is
+// empty if there are no matches.
+// Only the ID and ChannelName of the returned channels are populated.
+func (s *ServerMethods) ChannelFind(pattern string) ([]*Channel, error) {
+	return s.ChannelFindContext(context.Background(), pattern)
+}
+
+// ChannelFindContext returns the channels whose name matches pattern.
+func (s *ServerMethods) ChannelFindContext(ctx context.Context, pattern string) ([]*Channel, error) {
+	var channels []*Channel
+	if _, err := s.ExecCmdContext(ctx, NewCmd("channelfind").WithArgs(NewArg("pattern", pattern)).WithResponse(&channels)); err != nil {
+		if isEmptyResult(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return channels, nil
+}
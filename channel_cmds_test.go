@@ -0,0 +1,222 @@
+package ts3
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCmdsChannel(t *testing.T) {
+	s := newServer(t)
+	defer func() {
+		assert.NoError(t, s.Close())
+	}()
+
+	c, err := NewClient(s.Addr, Timeout(time.Second*2))
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	defer func() {
+		assert.NoError(t, c.Close())
+	}()
+
+	testCmdsChannel(t, c, s)
+}
+
+func TestCmdsChannelSSH(t *testing.T) {
+	s := newServer(t, useSSH())
+	defer func() {
+		assert.NoError(t, s.Close())
+	}()
+
+	c, err := NewClient(s.Addr, Timeout(time.Second*2), SSH(sshClientTestConfig))
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	defer func() {
+		assert.NoError(t, c.Close())
+	}()
+
+	testCmdsChannel(t, c, s)
+}
+
+func testCmdsChannel(t *testing.T, c *Client, s *server) {
+	t.Helper()
+	lastCmd := func() string {
+		received := s.Received()
+		return received[len(received)-1]
+	}
+
+	channellistextended := func(t *testing.T) {
+		t.Helper()
+		channels, err := c.Server.ChannelList(ChannelListFull)
+		if !assert.NoError(t, err) {
+			return
+		}
+
+		trueP := true
+		falseP := false
+		intptr := func(i int) *int {
+			return &i
+		}
+		stringptr := func(s string) *string {
+			return &s
+		}
+		codec := ChannelCodecOpusVoice
+
+		expected := []*Channel{
+			{
+				ID:           499,
+				ChannelName:  "Default Channel",
+				TotalClients: 1,
+				ChannelExt: &ChannelExt{
+					Topic: stringptr("Welcome"),
+					ChannelFlags: &ChannelFlags{
+						FlagDefault:       &trueP,
+						FlagPassword:      &falseP,
+						FlagPermanent:     &trueP,
+						FlagSemiPermanent: &falseP,
+					},
+					ChannelVoice: &ChannelVoice{
+						Codec:           &codec,
+						CodecQuality:    intptr(6),
+						NeededTalkPower: intptr(0),
+					},
+					ChannelLimits: &ChannelLimits{
+						TotalClientsFamily: intptr(1),
+						MaxClients:         intptr(-1),
+						MaxFamilyClients:   intptr(-1),
+					},
+					IconID:       intptr(0),
+					SecondsEmpty: intptr(-1),
+				},
+			},
+		}
+		assert.Equal(t, expected, channels)
+	}
+
+	channelinfo := func(t *testing.T) {
+		t.Helper()
+		info, err := c.Server.ChannelInfo(499)
+		if !assert.NoError(t, err) {
+			return
+		}
+
+		expected := &ChannelInfo{
+			Name:                          "Default Channel",
+			Topic:                         "Welcome",
+			Description:                   "The default",
+			Codec:                         ChannelCodecOpusVoice,
+			CodecQuality:                  6,
+			MaxClients:                    -1,
+			MaxFamilyClients:              -1,
+			FlagPermanent:                 true,
+			FlagDefault:                   true,
+			CodecLatencyFactor:            1,
+			CodecIsUnencrypted:            true,
+			FlagMaxClientsUnlimited:       true,
+			FlagMaxFamilyClientsUnlimited: true,
+			FilePath:                      "files/virtualserver_1/channel_499",
+			SecondsEmpty:                  -1,
+		}
+		assert.Equal(t, expected, info)
+		assert.Equal(t, ChannelPermanent, info.Lifetime())
+	}
+
+	channelcreate := func(t *testing.T) {
+		t.Helper()
+		id, err := c.Server.ChannelCreate("New Channel",
+			ChannelSemiPermanent,
+			ChannelCodecOpusMusic,
+			ChannelCodecQuality(10),
+			ChannelMaxClients(-1),
+			ChannelPassword("secret"),
+			ChannelTopic("topic"),
+			ChannelDescription("description"),
+		)
+		if !assert.NoError(t, err) {
+			return
+		}
+		assert.Equal(t, 500, id)
+		assert.Equal(t, `channelcreate channel_name=New\sChannel channel_flag_permanent=0 channel_flag_semi_permanent=1 channel_codec=5 channel_codec_quality=10 channel_maxclients=-1 channel_flag_maxclients_unlimited=1 channel_password=secret channel_topic=topic channel_description=description`, lastCmd())
+	}
+
+	channeledit := func(t *testing.T) {
+		t.Helper()
+		assert.NoError(t, c.Server.ChannelEdit(500, ChannelTopic("new topic"), ChannelMaxClients(10)))
+	}
+
+	channeldelete := func(t *testing.T) {
+		t.Helper()
+		assert.NoError(t, c.Server.ChannelDelete(500, true))
+	}
+
+	channelmove := func(t *testing.T) {
+		t.Helper()
+		assert.NoError(t, c.Server.ChannelMove(500, 499, 0))
+	}
+
+	channelfind := func(t *testing.T) {
+		t.Helper()
+		channels, err := c.Server.ChannelFind("Default")
+		if !assert.NoError(t, err) {
+			return
+		}
+
+		expected := []*Channel{
+			{ID: 499, ChannelName: "Default Channel"},
+			{ID: 501, ChannelName: "Default Two"},
+		}
+		assert.Equal(t, expected, channels)
+
+		channels, err = c.Server.ChannelFind("missing")
+		assert.NoError(t, err)
+		assert.Empty(t, channels)
+	}
+
+	tests := []struct {
+		name string
+		f    func(t *testing.T)
+	}{
+		{"channellistextended", channellistextended},
+		{"channelinfo", channelinfo},
+		{"channelcreate", channelcreate},
+		{"channeledit", channeledit},
+		{"channeldelete", channeldelete},
+		{"channelmove", channelmove},
+		{"channelfind", channelfind},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, tc.f)
+	}
+}
+
+func TestChannelProperties(t *testing.T) {
+	tests := map[string]struct {
+		arg    CmdArg
+		expect string
+	}{
+		"temporary":      {ChannelTemporary, "channel_flag_permanent=0 channel_flag_semi_permanent=0"},
+		"semi-permanent": {ChannelSemiPermanent, "channel_flag_permanent=0 channel_flag_semi_permanent=1"},
+		"permanent":      {ChannelPermanent, "channel_flag_permanent=1 channel_flag_semi_permanent=0"},
+		"codec":          {ChannelCodecOpusVoice, "channel_codec=4"},
+		"codec-arg":      {NewArg("channel_codec", ChannelCodecOpusMusic), "channel_codec=5"},
+		"quality":        {ChannelCodecQuality(7), "channel_codec_quality=7"},
+		"max-clients":    {ChannelMaxClients(5), "channel_maxclients=5 channel_flag_maxclients_unlimited=0"},
+		"unlimited":      {ChannelMaxClients(-1), "channel_maxclients=-1 channel_flag_maxclients_unlimited=1"},
+		"password":       {ChannelPassword("a b"), `channel_password=a\sb`},
+		"topic":          {ChannelTopic("a|b"), `channel_topic=a\pb`},
+		"description":    {ChannelDescription("a/b"), `channel_description=a\/b`},
+		"parent":         {ChannelParent(3), "cpid=3"},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, tc.expect, tc.arg.ArgString())
+		})
+	}
+}
@@ -0,0 +1,264 @@
+package ts3
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"sort"
+	"strings"
+)
+
+// SkipChildren can be returned from a ChannelTree.Walk function to skip
+// the children of the current channel.
+var SkipChildren = errors.New("skip children")
+
+// ChannelNode is a channel within a ChannelTree.
+type ChannelNode struct {
+	Channel  *Channel        `json:"channel"`
+	Clients  []*OnlineClient `json:"clients,omitempty"`
+	Children []*ChannelNode  `json:"children,omitempty"`
+	Parent   *ChannelNode    `json:"-"`
+}
+
+// Ancestors returns the ancestors of n, nearest first.
+func (n *ChannelNode) Ancestors() []*ChannelNode {
+	var nodes []*ChannelNode
+	for p := n.Parent; p != nil; p = p.Parent {
+		nodes = append(nodes, p)
+	}
+	return nodes
+}
+
+// Path returns the channel names from the root down to and including n.
+func (n *ChannelNode) Path() []string {
+	ancestors := n.Ancestors()
+	path := make([]string, len(ancestors)+1)
+	for i, p := range ancestors {
+		path[len(ancestors)-i-1] = p.Channel.ChannelName
+	}
+	path[len(ancestors)] = n.Channel.ChannelName
+	return path
+}
+
+// ChannelTree is the channel hierarchy of a server with siblings in
+// display order.
+type ChannelTree struct {
+	Roots []*ChannelNode
+	nodes map[int]*ChannelNode
+}
+
+// NewChannelTree builds a ChannelTree from the results of ChannelList and,
+// optionally, ClientList.
+//
+// Siblings are ordered as the TeamSpeak client displays them, where each
+// channel's ChannelOrder is the ID of the channel it follows. Channels whose
+// parent is unknown, or which would create a cycle of parents, are treated
+// as roots and clients in unknown channels are ignored.
+func NewChannelTree(channels []*Channel, clients []*OnlineClient) *ChannelTree {
+	t := &ChannelTree{nodes: make(map[int]*ChannelNode, len(channels))}
+	for _, ch := range channels {
+		t.nodes[ch.ID] = &ChannelNode{Channel: ch}
+	}
+
+	for _, ch := range channels {
+		if p, ok := t.nodes[ch.ParentID]; ok && ch.ParentID != ch.ID {
+			t.nodes[ch.ID].Parent = p
+		}
+	}
+	breakCycles(channels, t.nodes)
+
+	siblings := make(map[int][]*ChannelNode)
+	for _, ch := range channels {
+		n := t.nodes[ch.ID]
+		var pid int
+		if n.Parent != nil {
+			pid = n.Parent.Channel.ID
+		}
+		siblings[pid] = append(siblings[pid], n)
+	}
+
+	for pid, nodes := range siblings {
+		nodes = orderSiblings(nodes)
+		if pid == 0 {
+			t.Roots = nodes
+		} else {
+			t.nodes[pid].Children = nodes
+		}
+	}
+
+	for _, cl := range clients {
+		if n, ok := t.nodes[cl.ChannelID]; ok {
+			n.Clients = append(n.Clients, cl)
+		}
+	}
+
+	return t
+}
+
+// breakCycles detaches the first channel, in the order of channels, found
+// to be part of each cycle of parents so it becomes a root.
+func breakCycles(channels []*Channel, nodes map[int]*ChannelNode) {
+	done := make(map[*ChannelNode]bool, len(nodes))
+	for _, ch := range channels {
+		path := make(map[*ChannelNode]bool)
+		for n := nodes[ch.ID]; n != nil && !done[n]; n = n.Parent {
+			if path[n] {
+				n.Parent = nil
+				break
+			}
+			path[n] = true
+		}
+		for n := range path {
+			done[n] = true
+		}
+	}
+}
+
+// orderSiblings returns nodes ordered by following the ChannelOrder links.
+// Nodes which aren't reachable, due to a broken or cyclic chain, are
+// appended ordered by ID.
+func orderSiblings(nodes []*ChannelNode) []*ChannelNode {
+	after := make(map[int]*ChannelNode, len(nodes))
+	for _, n := range nodes {
+		if _, ok := after[n.Channel.ChannelOrder]; !ok {
+			after[n.Channel.ChannelOrder] = n
+		}
+	}
+
+	ordered := make([]*ChannelNode, 0, len(nodes))
+	seen := make(map[int]bool, len(nodes))
+	for prev := 0; ; {
+		n, ok := after[prev]
+		if !ok || seen[n.Channel.ID] {
+			break
+		}
+		seen[n.Channel.ID] = true
+		ordered = append(ordered, n)
+		prev = n.Channel.ID
+	}
+
+	if len(ordered) == len(nodes) {
+		return ordered
+	}
+
+	var rest []*ChannelNode
+	for _, n := range nodes {
+		if !seen[n.Channel.ID] {
+			rest = append(rest, n)
+		}
+	}
+	sort.Slice(rest, func(i, j int) bool {
+		return rest[i].Channel.ID < rest[j].Channel.ID
+	})
+
+	return append(ordered, rest...)
+}
+
+// Find returns the node for channel id or nil if not present.
+func (t *ChannelTree) Find(id int) *ChannelNode {
+	return t.nodes[id]
+}
+
+// FindPath returns the node reached by following the channel names in path
+// from the roots, or nil if there is no such channel.
+func (t *ChannelTree) FindPath(path ...string) *ChannelNode {
+	if len(path) == 0 {
+		return nil
+	}
+
+	var n *ChannelNode
+	nodes := t.Roots
+	for _, name := range path {
+		n = nil
+		for _, c := range nodes {
+			if c.Channel.ChannelName == name {
+				n = c
+				break
+			}
+		}
+		if n == nil {
+			return nil
+		}
+		nodes = n.Children
+	}
+
+	return n
+}
+
+// Walk calls fn for each channel in display order, parents before their
+// children, with the depth of the channel where roots have depth 0.
+// If fn returns SkipChildren the children of that channel are skipped,
+// any other error stops the walk and is returned.
+func (t *ChannelTree) Walk(fn func(n *ChannelNode, depth int) error) error {
+	return walkChannels(t.Roots, 0, fn)
+}
+
+// walkChannels calls fn for nodes and their descendants.
+func walkChannels(nodes []*ChannelNode, depth int, fn func(n *ChannelNode, depth int) error) error {
+	for _, n := range nodes {
+		switch err := fn(n, depth); err {
+		case nil:
+			if err := walkChannels(n.Children, depth+1, fn); err != nil {
+				return err
+			}
+		case SkipChildren:
+		default:
+			return err
+		}
+	}
+
+	return nil
+}
+
+// String returns the tree as indented text, one channel per line with its
+// clients listed below it.
+func (t *ChannelTree) String() string {
+	var b strings.Builder
+	t.Walk(func(n *ChannelNode, depth int) error { //nolint: errcheck
+		indent := strings.Repeat("  ", depth)
+		b.WriteString(indent)
+		b.WriteString(n.Channel.ChannelName)
+		b.WriteByte('\n')
+		for _, cl := range n.Clients {
+			b.WriteString(indent)
+			b.WriteString("  * ")
+			b.WriteString(cl.Nickname)
+			b.WriteByte('\n')
+		}
+		return nil
+	})
+
+	return b.String()
+}
+
+// MarshalJSON implements json.Marshaler.
+func (t *ChannelTree) MarshalJSON() ([]byte, error) {
+	roots := t.Roots
+	if roots == nil {
+		roots = []*ChannelNode{}
+	}
+	return json.Marshal(roots)
+}
+
+// ChannelTree returns the channel hierarchy of the selected server.
+// If clients is true each channel is populated with its online clients.
+func (s *ServerMethods) ChannelTree(clients bool) (*ChannelTree, error) {
+	return s.ChannelTreeContext(context.Background(), clients)
+}
+
+// ChannelTreeContext returns the channel hierarchy of the selected server.
+func (s *ServerMethods) ChannelTreeContext(ctx context.Context, clients bool) (*ChannelTree, error) {
+	channels, err := s.ChannelListContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var cls []*OnlineClient
+	if clients {
+		if cls, err = s.ClientListContext(ctx); err != nil {
+			return nil, err
+		}
+	}
+
+	return NewChannelTree(channels, cls), nil
+}
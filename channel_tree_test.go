@@ -0,0 +1,176 @@
+package ts3
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testChannelTree() *ChannelTree {
+	channels := []*Channel{
+		{ID: 3, ParentID: 0, ChannelOrder: 1, ChannelName: "Games"},
+		{ID: 1, ParentID: 0, ChannelOrder: 0, ChannelName: "Lobby"},
+		{ID: 5, ParentID: 3, ChannelOrder: 4, ChannelName: "Squad B"},
+		{ID: 4, ParentID: 3, ChannelOrder: 0, ChannelName: "Squad A"},
+		{ID: 6, ParentID: 0, ChannelOrder: 3, ChannelName: "AFK"},
+		{ID: 7, ParentID: 99, ChannelOrder: 0, ChannelName: "Orphan"},
+	}
+	clients := []*OnlineClient{
+		{ID: 10, ChannelID: 1, Nickname: "alice"},
+		{ID: 11, ChannelID: 5, Nickname: "bob"},
+		{ID: 12, ChannelID: 42, Nickname: "ghost"},
+	}
+	return NewChannelTree(channels, clients)
+}
+
+func TestChannelTree(t *testing.T) {
+	tree := testChannelTree()
+
+	var names []string
+	var depths []int
+	err := tree.Walk(func(n *ChannelNode, depth int) error {
+		names = append(names, n.Channel.ChannelName)
+		depths = append(depths, depth)
+		return nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"Lobby", "Games", "Squad A", "Squad B", "AFK", "Orphan"}, names)
+	assert.Equal(t, []int{0, 0, 1, 1, 0, 0}, depths)
+
+	assert.Equal(t, "Lobby\n  * alice\nGames\n  Squad A\n  Squad B\n    * bob\nAFK\nOrphan\n", tree.String())
+
+	n := tree.FindPath("Games", "Squad B")
+	require.NotNil(t, n)
+	assert.Equal(t, 5, n.Channel.ID)
+	assert.Equal(t, tree.Find(5), n)
+	assert.Equal(t, []string{"Games", "Squad B"}, n.Path())
+	assert.Equal(t, []*ChannelNode{tree.Find(3)}, n.Ancestors())
+	assert.Nil(t, tree.FindPath("Games", "Squad C"))
+	assert.Nil(t, tree.FindPath())
+	assert.Nil(t, tree.Find(99))
+}
+
+func TestChannelTreeWalkSkip(t *testing.T) {
+	tree := testChannelTree()
+
+	var names []string
+	err := tree.Walk(func(n *ChannelNode, depth int) error {
+		names = append(names, n.Channel.ChannelName)
+		if n.Channel.ID == 3 {
+			return SkipChildren
+		}
+		return nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"Lobby", "Games", "AFK", "Orphan"}, names)
+
+	errStop := errors.New("stop")
+	names = nil
+	err = tree.Walk(func(n *ChannelNode, depth int) error {
+		names = append(names, n.Channel.ChannelName)
+		if n.Channel.ID == 4 {
+			return errStop
+		}
+		return nil
+	})
+	assert.Equal(t, errStop, err)
+	assert.Equal(t, []string{"Lobby", "Games", "Squad A"}, names)
+}
+
+func TestChannelTreeBrokenOrder(t *testing.T) {
+	tree := NewChannelTree([]*Channel{
+		{ID: 2, ChannelOrder: 1},
+		{ID: 1, ChannelOrder: 2},
+		{ID: 3, ChannelOrder: 0},
+	}, nil)
+
+	var ids []int
+	require.NoError(t, tree.Walk(func(n *ChannelNode, depth int) error {
+		ids = append(ids, n.Channel.ID)
+		return nil
+	}))
+	assert.Equal(t, []int{3, 1, 2}, ids)
+}
+
+func TestChannelTreeParentCycle(t *testing.T) {
+	tree := NewChannelTree([]*Channel{
+		{ID: 1, ParentID: 3, ChannelName: "A"},
+		{ID: 2, ParentID: 1, ChannelName: "B"},
+		{ID: 3, ParentID: 2, ChannelName: "C"},
+		{ID: 4, ParentID: 2, ChannelName: "D"},
+		{ID: 5, ParentID: 5, ChannelName: "E"},
+	}, nil)
+
+	var ids, depths []int
+	require.NoError(t, tree.Walk(func(n *ChannelNode, depth int) error {
+		ids = append(ids, n.Channel.ID)
+		depths = append(depths, depth)
+		return nil
+	}))
+	assert.Equal(t, []int{1, 2, 3, 4, 5}, ids)
+	assert.Equal(t, []int{0, 1, 2, 2, 0}, depths)
+	assert.Equal(t, []string{"A", "B", "C"}, tree.Find(3).Path())
+	assert.Len(t, tree.Find(4).Ancestors(), 2)
+}
+
+func TestChannelTreeJSON(t *testing.T) {
+	tree := NewChannelTree([]*Channel{
+		{ID: 1, ChannelName: "Lobby"},
+		{ID: 2, ParentID: 1, ChannelName: "Sub"},
+	}, []*OnlineClient{{ID: 10, ChannelID: 2, Nickname: "alice"}})
+
+	data, err := json.Marshal(tree)
+	require.NoError(t, err)
+
+	var roots []struct {
+		Channel struct {
+			ID int
+		}
+		Children []struct {
+			Channel struct {
+				ChannelName string
+			}
+			Clients []struct {
+				Nickname string
+			}
+		}
+	}
+	require.NoError(t, json.Unmarshal(data, &roots))
+	require.Len(t, roots, 1)
+	assert.Equal(t, 1, roots[0].Channel.ID)
+	require.Len(t, roots[0].Children, 1)
+	assert.Equal(t, "Sub", roots[0].Children[0].Channel.ChannelName)
+	require.Len(t, roots[0].Children[0].Clients, 1)
+	assert.Equal(t, "alice", roots[0].Children[0].Clients[0].Nickname)
+
+	data, err = json.Marshal(NewChannelTree(nil, nil))
+	require.NoError(t, err)
+	assert.Equal(t, "[]", string(data))
+}
+
+func TestCmdsChannelTree(t *testing.T) {
+	s := newServer(t)
+	defer func() {
+		assert.NoError(t, s.Close())
+	}()
+
+	c, err := NewClient(s.Addr, Timeout(time.Second*2))
+	require.NoError(t, err)
+
+	defer func() {
+		assert.NoError(t, c.Close())
+	}()
+
+	tree, err := c.Server.ChannelTree(true)
+	require.NoError(t, err)
+	require.Len(t, tree.Roots, 1)
+	assert.Equal(t, "Default Channel", tree.Roots[0].Channel.ChannelName)
+	assert.Empty(t, tree.Roots[0].Clients)
+
+	received := s.Received()
+	assert.Equal(t, []string{"channellist", "clientlist"}, received[len(received)-2:])
+}
@@ -0,0 +1,174 @@
+package ts3
+
+import (
+	"context"
+)
+
+// ChannelGroup represents a virtual server channel group.
+type ChannelGroup struct {
+	ID                int `ms:"cgid"`
+	Name              string
+	Type              GroupType
+	IconID            int
+	Saved             bool `ms:"savedb"`
+	SortID            int
+	NameMode          int
+	ModifyPower       int `ms:"n_modifyp"`
+	MemberAddPower    int `ms:"n_member_addp"`
+	MemberRemovePower int `ms:"n_member_removep"`
+}
+
+// ChannelGroupList returns a list of available channel groups for the selected server.
+func (s *ServerMethods) ChannelGroupList() ([]*ChannelGroup, error) {
+	return s.ChannelGroupListContext(context.Background())
+}
+
+// ChannelGroupListContext returns a list of available channel groups for the selected server.
+func (s *ServerMethods) ChannelGroupListContext(ctx context.Context) ([]*ChannelGroup, error) {
+	var groups []*ChannelGroup
+	if _, err := s.ExecCmdContext(ctx, NewCmd("channelgrouplist").WithResponse(&groups)); err != nil {
+		return nil, err
+	}
+
+	return groups, nil
+}
+
+// ChannelGroupAdd creates a new channel group of type typ and returns its ID.
+func (s *ServerMethods) ChannelGroupAdd(name string, typ GroupType) (int, error) {
+	return s.ChannelGroupAddContext(context.Background(), name, typ)
+}
+
+// ChannelGroupAddContext creates a new channel group of type typ and returns its ID.
+func (s *ServerMethods) ChannelGroupAddContext(ctx context.Context, name string, typ GroupType) (int, error) {
+	r := struct {
+		ID int `ms:"cgid"`
+	}{}
+	if _, err := s.ExecCmdContext(ctx, NewCmd("channelgroupadd").WithArgs(
+		NewArg("name", name),
+		NewArg("type", int(typ)),
+	).WithResponse(&r)); err != nil {
+		return 0, err
+	}
+
+	return r.ID, nil
+}
+
+// ChannelGroupDel deletes the channel group specified by id.
+// If force is true the group is deleted even if it has members.
+func (s *ServerMethods) ChannelGroupDel(id int, force bool) error {
+	return s.ChannelGroupDelContext(context.Background(), id, force)
+}
+
+// ChannelGroupDelContext deletes the channel group specified by id.
+func (s *ServerMethods) ChannelGroupDelContext(ctx context.Context, id int, force bool) error {
+	_, err := s.ExecCmdContext(ctx, NewCmd("channelgroupdel").WithArgs(
+		NewArg("cgid", id),
+		NewArg("force", force),
+	))
+	return err
+}
+
+// ChannelGroupCopy copies the channel group sourceID, including its permissions,
+// to targetID. If targetID is 0 a new group called name of type typ is created
+// and its ID returned, otherwise targetID is overwritten and returned.
+func (s *ServerMethods) ChannelGroupCopy(sourceID, targetID int, name string, typ GroupType) (int, error) {
+	return s.ChannelGroupCopyContext(context.Background(), sourceID, targetID, name, typ)
+}
+
+// ChannelGroupCopyContext copies the channel group sourceID, including its permissions,
+// to targetID.
+func (s *ServerMethods) ChannelGroupCopyContext(ctx context.Context, sourceID, targetID int, name string, typ GroupType) (int, error) {
+	cmd := NewCmd("channelgroupcopy").WithArgs(
+		NewArg("scgid", sourceID),
+		NewArg("tcgid", targetID),
+		NewArg("name", name),
+		NewArg("type", int(typ)),
+	)
+
+	// The server only returns the ID when a new group is created.
+	if targetID != 0 {
+		if _, err := s.ExecCmdContext(ctx, cmd); err != nil {
+			return 0, err
+		}
+		return targetID, nil
+	}
+
+	r := struct {
+		ID int `ms:"cgid"`
+	}{}
+	if _, err := s.ExecCmdContext(ctx, cmd.WithResponse(&r)); err != nil {
+		return 0, err
+	}
+
+	return r.ID, nil
+}
+
+// ChannelGroupRename changes the name of the channel group specified by id.
+func (s *ServerMethods) ChannelGroupRename(id int, name string) error {
+	return s.ChannelGroupRenameContext(context.Background(), id, name)
+}
+
+// ChannelGroupRenameContext changes the name of the channel group specified by id.
+func (s *ServerMethods) ChannelGroupRenameContext(ctx context.Context, id int, name string) error {
+	_, err := s.ExecCmdContext(ctx, NewCmd("channelgrouprename").WithArgs(
+		NewArg("cgid", id),
+		NewArg("name", name),
+	))
+	return err
+}
+
+// ChannelGroupClient represents a client's channel group in a channel.
+type ChannelGroupClient struct {
+	ChannelID  int `ms:"cid"`
+	DatabaseID int `ms:"cldbid"`
+	GroupID    int `ms:"cgid"`
+}
+
+// ChannelGroupClientList returns the channel group assignments filtered by
+// channel id, client database ID dbID and channel group groupID. Filters
+// which are 0 are ignored. If nothing matches the list is empty.
+func (s *ServerMethods) ChannelGroupClientList(id, dbID, groupID int) ([]*ChannelGroupClient, error) {
+	return s.ChannelGroupClientListContext(context.Background(), id, dbID, groupID)
+}
+
+// ChannelGroupClientListContext returns the channel group assignments filtered by
+// channel id, client database ID dbID and channel group groupID.
+func (s *ServerMethods) ChannelGroupClientListContext(ctx context.Context, id, dbID, groupID int) ([]*ChannelGroupClient, error) {
+	var args []CmdArg
+	if id != 0 {
+		args = append(args, NewArg("cid", id))
+	}
+	if dbID != 0 {
+		args = append(args, NewArg("cldbid", dbID))
+	}
+	if groupID != 0 {
+		args = append(args, NewArg("cgid", groupID))
+	}
+
+	var clients []*ChannelGroupClient
+	if _, err := s.ExecCmdContext(ctx, NewCmd("channelgroupclientlist").WithArgs(args...).WithResponse(&clients)); err != nil {
+		if isEmptyResult(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return clients, nil
+}
+
+// SetClientChannelGroup sets the channel group of the client with database
+// ID dbID in channel id to groupID.
+func (s *ServerMethods) SetClientChannelGroup(groupID, id, dbID int) error {
+	return s.SetClientChannelGroupContext(context.Background(), groupID, id, dbID)
+}
+
+// SetClientChannelGroupContext sets the channel group of the client with database
+// ID dbID in channel id to groupID.
+func (s *ServerMethods) SetClientChannelGroupContext(ctx context.Context, groupID, id, dbID int) error {
+	_, err := s.ExecCmdContext(ctx, NewCmd("setclientchannelgroup").WithArgs(
+		NewArg("cgid", groupID),
+		NewArg("cid", id),
+		NewArg("cldbid", dbID),
+	))
+	return err
+}
@@ -0,0 +1,165 @@
+package ts3
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCmdsChannelGroup(t *testing.T) {
+	s := newServer(t)
+	defer func() {
+		assert.NoError(t, s.Close())
+	}()
+
+	c, err := NewClient(s.Addr, Timeout(time.Second*2))
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	defer func() {
+		assert.NoError(t, c.Close())
+	}()
+
+	testCmdsChannelGroup(t, c, s)
+}
+
+func TestCmdsChannelGroupSSH(t *testing.T) {
+	s := newServer(t, useSSH())
+	defer func() {
+		assert.NoError(t, s.Close())
+	}()
+
+	c, err := NewClient(s.Addr, Timeout(time.Second*2), SSH(sshClientTestConfig))
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	defer func() {
+		assert.NoError(t, c.Close())
+	}()
+
+	testCmdsChannelGroup(t, c, s)
+}
+
+func testCmdsChannelGroup(t *testing.T, c *Client, s *server) {
+	t.Helper()
+	lastCmd := func() string {
+		received := s.Received()
+		return received[len(received)-1]
+	}
+
+	channelgrouplist := func(t *testing.T) {
+		t.Helper()
+		groups, err := c.Server.ChannelGroupList()
+		if !assert.NoError(t, err) {
+			return
+		}
+
+		expected := []*ChannelGroup{
+			{
+				ID:                5,
+				Name:              "Channel Admin",
+				Type:              GroupTypeRegular,
+				IconID:            100,
+				Saved:             true,
+				ModifyPower:       75,
+				MemberAddPower:    50,
+				MemberRemovePower: 50,
+			},
+			{
+				ID:          8,
+				Name:        "Guest",
+				Type:        GroupTypeRegular,
+				ModifyPower: 75,
+			},
+		}
+		assert.Equal(t, expected, groups)
+	}
+
+	channelgroupadd := func(t *testing.T) {
+		t.Helper()
+		id, err := c.Server.ChannelGroupAdd("Moderator", GroupTypeRegular)
+		if !assert.NoError(t, err) {
+			return
+		}
+		assert.Equal(t, 12, id)
+		assert.Equal(t, "channelgroupadd name=Moderator type=1", lastCmd())
+	}
+
+	channelgroupdel := func(t *testing.T) {
+		t.Helper()
+		assert.NoError(t, c.Server.ChannelGroupDel(12, false))
+		assert.Equal(t, "channelgroupdel cgid=12 force=0", lastCmd())
+	}
+
+	channelgroupcopy := func(t *testing.T) {
+		t.Helper()
+		id, err := c.Server.ChannelGroupCopy(5, 0, "Admin Copy", GroupTypeRegular)
+		if !assert.NoError(t, err) {
+			return
+		}
+		assert.Equal(t, 13, id)
+		assert.Equal(t, `channelgroupcopy scgid=5 tcgid=0 name=Admin\sCopy type=1`, lastCmd())
+
+		id, err = c.Server.ChannelGroupCopy(5, 14, "Admin Copy", GroupTypeRegular)
+		if !assert.NoError(t, err) {
+			return
+		}
+		assert.Equal(t, 14, id)
+		assert.Equal(t, `channelgroupcopy scgid=5 tcgid=14 name=Admin\sCopy type=1`, lastCmd())
+	}
+
+	channelgrouprename := func(t *testing.T) {
+		t.Helper()
+		assert.NoError(t, c.Server.ChannelGroupRename(12, "Mod"))
+		assert.Equal(t, "channelgrouprename cgid=12 name=Mod", lastCmd())
+	}
+
+	channelgroupclientlist := func(t *testing.T) {
+		t.Helper()
+		clients, err := c.Server.ChannelGroupClientList(0, 7, 0)
+		if !assert.NoError(t, err) {
+			return
+		}
+
+		expected := []*ChannelGroupClient{
+			{ChannelID: 499, DatabaseID: 7, GroupID: 5},
+			{ChannelID: 501, DatabaseID: 7, GroupID: 8},
+		}
+		assert.Equal(t, expected, clients)
+		assert.Equal(t, "channelgroupclientlist cldbid=7", lastCmd())
+
+		_, err = c.Server.ChannelGroupClientList(499, 0, 5)
+		assert.NoError(t, err)
+		assert.Equal(t, "channelgroupclientlist cid=499 cgid=5", lastCmd())
+
+		clients, err = c.Server.ChannelGroupClientList(501, 0, 5)
+		assert.NoError(t, err)
+		assert.Empty(t, clients)
+	}
+
+	setclientchannelgroup := func(t *testing.T) {
+		t.Helper()
+		assert.NoError(t, c.Server.SetClientChannelGroup(5, 499, 7))
+		assert.Equal(t, "setclientchannelgroup cgid=5 cid=499 cldbid=7", lastCmd())
+	}
+
+	tests := []struct {
+		name string
+		f    func(t *testing.T)
+	}{
+		{"channelgrouplist", channelgrouplist},
+		{"channelgroupadd", channelgroupadd},
+		{"channelgroupdel", channelgroupdel},
+		{"channelgroupcopy", channelgroupcopy},
+		{"channelgrouprename", channelgrouprename},
+		{"channelgroupclientlist", channelgroupclientlist},
+		{"setclientchannelgroup", setclientchannelgroup},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, tc.f)
+	}
+}
@@ -2,6 +2,7 @@ package ts3
 
 import (
 	"bufio"
+	"context"
 	"fmt"
 	"io"
 	"net"
@@ -62,8 +63,20 @@ type response struct {
 	lines []string
 }
 
+// request is a command waiting to be processed by workHandler.
+type request struct {
+	cmd string
+
+	// response receives the result of cmd. It's buffered so that
+	// workHandler never blocks if the caller has given up waiting.
+	response chan response
+}
+
 // Client is a TeamSpeak 3 ServerQuery client.
 type Client struct {
+	stats NotificationStats // stats is first to ensure 64-bit alignment for atomic access.
+
+	addr          string
 	conn          Connection
 	timeout       time.Duration
 	keepAlive     time.Duration
@@ -71,14 +84,32 @@ type Client struct {
 	buf           []byte
 	maxBufSize    int
 	notifyBufSize int
-	work          chan string
+	work          chan *request
 	response      chan response
 	notify        chan Notification
 	closing       chan struct{} // closing is closed to indicate we're closing our connection.
-	done          chan struct{} // done is closed once we're seen a fatal error.
+	done          chan struct{} // done is closed once the client is no longer usable.
 	doneOnce      sync.Once
 	connectHeader string
 	wg            sync.WaitGroup
+	reconnect     *ReconnectPolicy
+	state         sessionState
+	eventWorkers  int
+	dispatcher    *dispatcher
+	perms         permCache
+	ids           *idCache
+	transferID    uint32 // transferID is the last client file transfer ID, accessed atomically.
+
+	overflow           OverflowPolicy
+	notifyBlockTimeout time.Duration
+	notifyQueue        *notificationQueue
+	onDropped          func(Notification)
+	onUndecodable      func(string, error)
+
+	// Below here is protected by connMtx.
+	connMtx  sync.Mutex
+	connOpen bool
+	session  *session
 
 	Server *ServerMethods
 }
@@ -100,6 +131,7 @@ func KeepAlive(keepAlive time.Duration) func(*Client) error {
 }
 
 // NotificationBuffer sets the notification buffer size.
+// See NotificationOverflow for how a full buffer is handled.
 func NotificationBuffer(size int) func(*Client) error {
 	return func(c *Client) error {
 		c.notifyBufSize = size
@@ -156,17 +188,21 @@ func SSH(config *ssh.ClientConfig) func(*Client) error {
 // Use with SSH where possible for improved security.
 func NewClient(addr string, options ...func(c *Client) error) (*Client, error) {
 	c := &Client{
+		addr:          addr,
 		conn:          new(legacyConnection),
 		timeout:       DefaultTimeout,
 		keepAlive:     DefaultKeepAlive,
 		buf:           make([]byte, startBufSize),
 		maxBufSize:    MaxParseTokenSize,
 		notifyBufSize: DefaultNotifyBufSize,
-		work:          make(chan string),
+		work:          make(chan *request),
 		response:      make(chan response),
 		closing:       make(chan struct{}),
 		done:          make(chan struct{}),
 		connectHeader: DefaultConnectHeader,
+		eventWorkers:  DefaultEventWorkers,
+
+		notifyBlockTimeout: DefaultNotifyBlockTimeout,
 	}
 	for _, f := range options {
 		if f == nil {
@@ -178,59 +214,171 @@ func NewClient(addr string, options ...func(c *Client) error) (*Client, error) {
 	}
 
 	c.notify = make(chan Notification, c.notifyBufSize)
+	c.dispatcher = newDispatcher(c.eventWorkers)
+	if c.overflow == OverflowUnbounded {
+		c.notifyQueue = newNotificationQueue()
+	}
 
 	// Wire up command groups
 	c.Server = &ServerMethods{Client: c}
 
-	if err := c.conn.Connect(addr, c.timeout); err != nil {
-		return nil, fmt.Errorf("client: connect: %w", err)
+	if err := c.connect(); err != nil {
+		return nil, err
+	}
+
+	if c.notifyQueue != nil {
+		go c.forwardNotifications()
+	}
+
+	// Start handlers
+	s := c.startSession()
+	s.start(c.workHandler)
+
+	c.wg.Add(1)
+	go c.run(s)
+
+	return c, nil
+}
+
+// connect connects to the server and reads the connection header and banner.
+func (c *Client) connect() error {
+	if err := c.conn.Connect(c.addr, c.timeout); err != nil {
+		return fmt.Errorf("client: connect: %w", err)
 	}
 
+	c.connMtx.Lock()
+	c.connOpen = true
+	c.connMtx.Unlock()
+
 	c.scanner = bufio.NewScanner(bufio.NewReader(c.conn))
 	c.scanner.Buffer(c.buf, c.maxBufSize)
 	c.scanner.Split(ScanLines)
 
 	if err := c.conn.SetDeadline(time.Now().Add(c.timeout)); err != nil {
-		return nil, fmt.Errorf("client: set deadline: %w", err)
+		return fmt.Errorf("client: set deadline: %w", err)
 	}
 
 	// Read the connection header
 	if !c.scanner.Scan() {
-		return nil, fmt.Errorf("client: header: %w", c.scanErr())
+		return fmt.Errorf("client: header: %w", c.scanErr())
 	}
 
 	if l := c.scanner.Text(); l != c.connectHeader {
-		return nil, fmt.Errorf("client: invalid connection header %q", l)
+		return fmt.Errorf("client: invalid connection header %q", l)
 	}
 
 	// Slurp the banner
 	if !c.scanner.Scan() {
-		return nil, fmt.Errorf("client: banner: %w", c.scanErr())
+		return fmt.Errorf("client: banner: %w", c.scanErr())
 	}
 
 	if err := c.conn.SetReadDeadline(time.Time{}); err != nil {
-		return nil, fmt.Errorf("client: set read deadline: %w", err)
+		return fmt.Errorf("client: set read deadline: %w", err)
 	}
 
-	// Start handlers
-	c.wg.Add(2)
-	go c.messageHandler()
-	go c.workHandler()
+	return nil
+}
 
-	return c, nil
+// closeConn closes the current connection if it's still open.
+func (c *Client) closeConn() error {
+	c.connMtx.Lock()
+	defer c.connMtx.Unlock()
+
+	if !c.connOpen {
+		return nil
+	}
+	c.connOpen = false
+
+	return c.conn.Close() //nolint: wrapcheck
+}
+
+// session tracks the handlers processing a single connection.
+type session struct {
+	lost     chan struct{} // lost is closed once the connection has seen a fatal error.
+	lostOnce sync.Once
+	wg       sync.WaitGroup
+}
+
+// startSession starts a new session for the current connection
+// with its messageHandler running.
+func (c *Client) startSession() *session {
+	s := &session{lost: make(chan struct{})}
+
+	c.connMtx.Lock()
+	c.session = s
+	c.connMtx.Unlock()
+
+	s.start(c.messageHandler)
+
+	return s
+}
+
+// start runs f in a new goroutine tracked by the session.
+func (s *session) start(f func(*session)) {
+	s.wg.Add(1)
+	go func() {
+		defer s.wg.Done()
+		f(s)
+	}()
 }
 
 // fatalError returns false if err is nil otherwise it ensures
-// that done is closed and returns true.
-func (c *Client) fatalError(err error) bool {
+// that lost is closed and returns true.
+func (s *session) fatalError(err error) bool {
 	if err == nil {
 		return false
 	}
 
-	c.closeDone()
+	s.close()
 	return true
 }
 
+// close safely closes s.lost.
+func (s *session) close() {
+	s.lostOnce.Do(func() {
+		close(s.lost)
+	})
+}
+
+// run monitors the session s, reconnecting if configured to do so,
+// until the client is closed or the connection can't be restored.
+func (c *Client) run(s *session) {
+	defer func() {
+		c.closeNotify()
+		c.dispatcher.close()
+		c.wg.Done()
+	}()
+
+	for {
+		<-s.lost
+
+		if c.reconnect == nil || c.isClosing() {
+			c.closeDone()
+			s.wg.Wait()
+			return
+		}
+
+		c.event(ConnectionEvent{State: ConnectionDisconnected})
+		c.closeConn() //nolint: errcheck
+		s.wg.Wait()
+
+		if s = c.reconnectSession(); s == nil {
+			c.closeDone()
+			return
+		}
+	}
+}
+
+// isClosing returns true if Close has been called.
+func (c *Client) isClosing() bool {
+	select {
+	case <-c.closing:
+		return true
+	default:
+		return false
+	}
+}
+
 // closeDone safely closes c.done.
 func (c *Client) closeDone() {
 	c.doneOnce.Do(func() {
@@ -239,15 +387,10 @@ func (c *Client) closeDone() {
 }
 
 // messageHandler scans incoming lines and handles them accordingly.
-// - Notifications are sent to c.notify.
+// - Notifications are delivered to c.notify and dispatched to subscriptions.
 // - ExecCmd responses are sent to c.response.
 // If a fatal error occurs it stops processing and exits.
-func (c *Client) messageHandler() {
-	defer func() {
-		close(c.notify)
-		c.wg.Done()
-	}()
-
+func (c *Client) messageHandler(s *session) {
 	buf := make([]string, 0, 10)
 	for {
 		if c.scanner.Scan() {
@@ -259,37 +402,61 @@ func (c *Client) messageHandler() {
 					resp.lines = buf
 					buf = make([]string, 0, 10)
 				}
-				c.response <- resp
+				c.sendResponse(s, resp)
 			} else if matches := respTrailerRe.FindStringSubmatch(line); len(matches) == 4 {
-				c.response <- response{err: NewError(matches)}
+				c.sendResponse(s, response{err: NewError(matches)})
 				// Avoid creating a new buf if there was no data in the response.
 				if len(buf) > 0 {
 					buf = make([]string, 0, 10)
 				}
 			} else if strings.Index(line, "notify") == 0 {
 				if n, err := decodeNotification(line); err == nil {
-					// non-blocking write
-					select {
-					case c.notify <- n:
-					default:
-					}
+					c.deliverNotification(n)
+					c.dispatchNotification(line, n)
+				} else {
+					c.undecodableNotification(line, err)
 				}
 			} else {
 				// Partial response.
 				buf = append(buf, line)
 			}
 		} else {
-			if err := c.scanErr(); c.fatalError(err) {
+			if err := c.scanErr(); err != nil {
+				// Inform any waiting command before signalling lost so
+				// the caller sees the real error not ErrNotConnected.
 				c.responseErr(err)
-			} else {
-				// Ensure that done is closed as scanner has seen an io.EOF.
-				c.closeDone()
 			}
+			// Ensure that lost is closed as scanner has seen an error or io.EOF.
+			s.close()
 			return
 		}
 	}
 }
 
+// dispatchNotification sends the event decoded from n, received as line,
+// to any subscriptions. If n can't be decoded n itself is dispatched.
+// The event is decoded even if there are no subscriptions so undecodable
+// notifications are always reported.
+func (c *Client) dispatchNotification(line string, n Notification) {
+	e, err := n.Event()
+	if err != nil {
+		c.undecodableNotification(line, err)
+		e = n
+	}
+
+	if c.dispatcher.active() {
+		c.dispatcher.dispatch(e)
+	}
+}
+
+// sendResponse sends resp to c.response unless the session is lost.
+func (c *Client) sendResponse(s *session, resp response) {
+	select {
+	case c.response <- resp:
+	case <-s.lost:
+	}
+}
+
 // responseErr sends err to c.response with a timeout to ensure it
 // doesn't block forever when multiple errors occur during the
 // processing of a single ExecCmd call.
@@ -304,30 +471,55 @@ func (c *Client) responseErr(err error) {
 }
 
 // workHandler handles commands and keepAlive messages.
-func (c *Client) workHandler() {
-	defer c.wg.Done()
-
+//
+// Commands are processed one at a time and the response to each is
+// consumed here, even if the caller has stopped waiting for it, so that
+// an abandoned command can't desynchronise responses for the next caller.
+func (c *Client) workHandler(s *session) {
 	for {
 		select {
-		case w := <-c.work:
-			if err := c.write([]byte(w)); c.fatalError(err) {
+		case req := <-c.work:
+			if err := c.write([]byte(req.cmd)); s.fatalError(err) {
 				// Command send failed, inform the caller.
-				c.responseErr(err)
+				req.response <- response{err: err}
+				return
+			}
+
+			if !c.waitResponse(s, req) {
 				return
 			}
 		case <-time.After(c.keepAlive):
 			// Send a keep alive to prevent the connection from timing out.
-			if err := c.write(keepAliveData); c.fatalError(err) {
+			if err := c.write(keepAliveData); s.fatalError(err) {
 				// We don't send to c.response as no ExecCmd is expecting a
 				// response and the next caller will get an error.
 				return
 			}
-		case <-c.done:
+		case <-s.lost:
 			return
 		}
 	}
 }
 
+// waitResponse waits for the response to req and passes it on to the caller.
+// It returns false if the session is lost.
+func (c *Client) waitResponse(s *session, req *request) bool {
+	t := time.NewTimer(c.timeout)
+	defer t.Stop()
+
+	select {
+	case resp := <-c.response:
+		req.response <- resp
+	case <-t.C:
+		req.response <- response{err: ErrTimeout}
+	case <-s.lost:
+		req.response <- response{err: ErrNotConnected}
+		return false
+	}
+
+	return true
+}
+
 // write writes data to the clients connection with the configured timeout
 // returning any error.
 func (c *Client) write(data []byte) error {
@@ -343,25 +535,58 @@ func (c *Client) write(data []byte) error {
 
 // Exec executes cmd on the server and returns the response.
 func (c *Client) Exec(cmd string) ([]string, error) {
-	return c.ExecCmd(NewCmd(cmd))
+	return c.ExecContext(context.Background(), cmd)
+}
+
+// ExecContext executes cmd on the server and returns the response.
+// See ExecCmdContext for details on how ctx is used.
+func (c *Client) ExecContext(ctx context.Context, cmd string) ([]string, error) {
+	return c.ExecCmdContext(ctx, NewCmd(cmd))
 }
 
 // ExecCmd executes cmd on the server and returns the response.
 func (c *Client) ExecCmd(cmd *Cmd) ([]string, error) {
+	return c.ExecCmdContext(context.Background(), cmd)
+}
+
+// ExecCmdContext executes cmd on the server and returns the response.
+//
+// If ctx is done before the response is received ctx.Err() is returned.
+// The configured Timeout still applies, whichever expires first wins.
+// The response to a cancelled command is discarded once it arrives so
+// it doesn't affect subsequent commands.
+//
+// If the client is reconnecting cmd is sent once the connection has been
+// restored, subject to the same ctx and Timeout limits.
+func (c *Client) ExecCmdContext(ctx context.Context, cmd *Cmd) ([]string, error) {
+	req := &request{
+		cmd:      cmd.String(),
+		response: make(chan response, 1),
+	}
+
 	select {
-	case c.work <- cmd.String():
+	case c.work <- req:
 	case <-c.done:
 		return nil, ErrNotConnected
+	case <-ctx.Done():
+		return nil, ctx.Err()
 	}
 
+	// The timeout only applies once the command is sent so time spent
+	// waiting behind other callers isn't counted.
+	t := time.NewTimer(c.timeout)
+	defer t.Stop()
+
 	var resp response
 	select {
-	case resp = <-c.response:
+	case resp = <-req.response:
 		if resp.err != nil {
 			return nil, resp.err
 		}
-	case <-time.After(c.timeout):
+	case <-t.C:
 		return nil, ErrTimeout
+	case <-ctx.Done():
+		return nil, ctx.Err()
 	}
 
 	if cmd.response != nil {
@@ -379,6 +604,16 @@ func (c *Client) IsConnected() bool {
 	select {
 	case <-c.done:
 		return false
+	default:
+	}
+
+	c.connMtx.Lock()
+	s := c.session
+	c.connMtx.Unlock()
+
+	select {
+	case <-s.lost:
+		return false
 	default:
 		return true
 	}
@@ -391,7 +626,7 @@ func (c *Client) Close() error {
 	// Signal we're expecting EOF.
 	close(c.closing)
 	_, err := c.Exec("quit")
-	err2 := c.conn.Close()
+	err2 := c.closeConn()
 
 	if err != nil {
 		return err
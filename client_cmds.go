@@ -0,0 +1,184 @@
+package ts3
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// ClientConnection represents the connection statistics of an online client.
+type ClientConnection struct {
+	FileTransferBandwidthSent     int    `ms:"connection_filetransfer_bandwidth_sent"`
+	FileTransferBandwidthReceived int    `ms:"connection_filetransfer_bandwidth_received"`
+	PacketsSentTotal              uint64 `ms:"connection_packets_sent_total"`
+	BytesSentTotal                uint64 `ms:"connection_bytes_sent_total"`
+	PacketsReceivedTotal          uint64 `ms:"connection_packets_received_total"`
+	BytesReceivedTotal            uint64 `ms:"connection_bytes_received_total"`
+	BandwidthSentLastSecond       int    `ms:"connection_bandwidth_sent_last_second_total"`
+	BandwidthSentLastMinute       int    `ms:"connection_bandwidth_sent_last_minute_total"`
+	BandwidthReceivedLastSecond   int    `ms:"connection_bandwidth_received_last_second_total"`
+	BandwidthReceivedLastMinute   int    `ms:"connection_bandwidth_received_last_minute_total"`
+	ConnectedTime                 int    `ms:"connection_connected_time"` // In milliseconds.
+	IP                            string `ms:"connection_client_ip"`
+}
+
+// ClientDetails represents detailed information about an online client.
+type ClientDetails struct {
+	ChannelID                      int       `ms:"cid"`
+	IdleTime                       int       `ms:"client_idle_time"` // In milliseconds.
+	UniqueIdentifier               string    `ms:"client_unique_identifier"`
+	Nickname                       string    `ms:"client_nickname"`
+	Version                        string    `ms:"client_version"`
+	Platform                       string    `ms:"client_platform"`
+	InputMuted                     bool      `ms:"client_input_muted"`
+	OutputMuted                    bool      `ms:"client_output_muted"`
+	OutputOnlyMuted                bool      `ms:"client_outputonly_muted"`
+	InputHardware                  bool      `ms:"client_input_hardware"`
+	OutputHardware                 bool      `ms:"client_output_hardware"`
+	DefaultChannel                 string    `ms:"client_default_channel"`
+	MetaData                       string    `ms:"client_meta_data"`
+	IsRecording                    bool      `ms:"client_is_recording"`
+	VersionSign                    string    `ms:"client_version_sign"`
+	SecurityHash                   string    `ms:"client_security_hash"`
+	LoginName                      string    `ms:"client_login_name"`
+	DatabaseID                     int       `ms:"client_database_id"`
+	ChannelGroupID                 int       `ms:"client_channel_group_id"`
+	ServerGroups                   []int     `ms:"client_servergroups"`
+	Created                        time.Time `ms:"client_created"`
+	LastConnected                  time.Time `ms:"client_lastconnected"`
+	TotalConnections               int       `ms:"client_totalconnections"`
+	Away                           bool      `ms:"client_away"`
+	AwayMessage                    string    `ms:"client_away_message"`
+	Type                           int       `ms:"client_type"`
+	FlagAvatar                     string    `ms:"client_flag_avatar"`
+	TalkPower                      int       `ms:"client_talk_power"`
+	TalkRequest                    bool      `ms:"client_talk_request"`
+	TalkRequestMessage             string    `ms:"client_talk_request_msg"`
+	Description                    string    `ms:"client_description"`
+	IsTalker                       bool      `ms:"client_is_talker"`
+	MonthBytesUploaded             uint64    `ms:"client_month_bytes_uploaded"`
+	MonthBytesDownloaded           uint64    `ms:"client_month_bytes_downloaded"`
+	TotalBytesUploaded             uint64    `ms:"client_total_bytes_uploaded"`
+	TotalBytesDownloaded           uint64    `ms:"client_total_bytes_downloaded"`
+	IsPrioritySpeaker              bool      `ms:"client_is_priority_speaker"`
+	NicknamePhonetic               string    `ms:"client_nickname_phonetic"`
+	NeededServerQueryViewPower     int       `ms:"client_needed_serverquery_view_power"`
+	DefaultToken                   string    `ms:"client_default_token"`
+	IconID                         int       `ms:"client_icon_id"`
+	IsChannelCommander             bool      `ms:"client_is_channel_commander"`
+	Country                        string    `ms:"client_country"`
+	ChannelGroupInheritedChannelID int       `ms:"client_channel_group_inherited_channel_id"`
+	Badges                         string    `ms:"client_badges"`
+	ClientConnection               `ms:",squash"`
+}
+
+// ClientInfo returns detailed information about the online client id.
+func (s *ServerMethods) ClientInfo(id int) (*ClientDetails, error) {
+	return s.ClientInfoContext(context.Background(), id)
+}
+
+// ClientInfoContext returns detailed information about the online client id.
+func (s *ServerMethods) ClientInfoContext(ctx context.Context, id int) (*ClientDetails, error) {
+	c := &ClientDetails{}
+	if _, err := s.ExecCmdContext(ctx, NewCmd("clientinfo").WithArgs(NewArg("clid", id)).WithResponse(c)); err != nil {
+		return nil, err
+	}
+
+	return c, nil
+}
+
+// ClientFind returns the online clients whose nickname matches pattern,
+// which is empty if there are no matches.
+// Only ID and Nickname are populated.
+func (s *ServerMethods) ClientFind(pattern string) ([]*OnlineClient, error) {
+	return s.ClientFindContext(context.Background(), pattern)
+}
+
+// ClientFindContext returns the online clients whose nickname matches pattern.
+func (s *ServerMethods) ClientFindContext(ctx context.Context, pattern string) ([]*OnlineClient, error) {
+	var clients []*OnlineClient
+	if _, err := s.ExecCmdContext(ctx, NewCmd("clientfind").WithArgs(NewArg("pattern", pattern)).WithResponse(&clients)); err != nil {
+		if isEmptyResult(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return clients, nil
+}
+
+// ClientMove moves the online clients ids to channel id.
+// If the channel has a password it must be passed, otherwise password
+// should be empty.
+func (s *ServerMethods) ClientMove(id int, password string, ids ...int) error {
+	return s.ClientMoveContext(context.Background(), id, password, ids...)
+}
+
+// ClientMoveContext moves the online clients ids to channel id.
+func (s *ServerMethods) ClientMoveContext(ctx context.Context, id int, password string, ids ...int) error {
+	if len(ids) == 0 {
+		return errors.New("client move: no clients")
+	}
+
+	args := []CmdArg{idGroup("clid", ids), NewArg("cid", id)}
+	if password != "" {
+		args = append(args, NewArg("cpw", password))
+	}
+
+	_, err := s.ExecCmdContext(ctx, NewCmd("clientmove").WithArgs(args...))
+	return err
+}
+
+// ClientKick kicks the online clients ids from their channel, if reason is
+// ReasonChannelKick, or from the server, if reason is ReasonServerKick.
+// If msg isn't empty it's sent to the clients as the reason.
+func (s *ServerMethods) ClientKick(reason ReasonID, msg string, ids ...int) error {
+	return s.ClientKickContext(context.Background(), reason, msg, ids...)
+}
+
+// ClientKickContext kicks the online clients ids from their channel or from the server.
+func (s *ServerMethods) ClientKickContext(ctx context.Context, reason ReasonID, msg string, ids ...int) error {
+	if reason != ReasonChannelKick && reason != ReasonServerKick {
+		return fmt.Errorf("client kick: invalid reason %d", reason)
+	}
+
+	if len(ids) == 0 {
+		return errors.New("client kick: no clients")
+	}
+
+	args := []CmdArg{idGroup("clid", ids), NewArg("reasonid", int(reason))}
+	if msg != "" {
+		args = append(args, NewArg("reasonmsg", msg))
+	}
+
+	_, err := s.ExecCmdContext(ctx, NewCmd("clientkick").WithArgs(args...))
+	return err
+}
+
+// ClientPoke sends a poke message to the online client id.
+func (s *ServerMethods) ClientPoke(id int, msg string) error {
+	return s.ClientPokeContext(context.Background(), id, msg)
+}
+
+// ClientPokeContext sends a poke message to the online client id.
+func (s *ServerMethods) ClientPokeContext(ctx context.Context, id int, msg string) error {
+	_, err := s.ExecCmdContext(ctx, NewCmd("clientpoke").WithArgs(
+		NewArg("clid", id),
+		NewArg("msg", msg),
+	))
+	return err
+}
+
+// ClientEdit changes the properties of the online client id e.g.
+// NewArg(ClientIsTalker, true) or NewArg(ClientDescription, "text").
+func (s *ServerMethods) ClientEdit(id int, props ...CmdArg) error {
+	return s.ClientEditContext(context.Background(), id, props...)
+}
+
+// ClientEditContext changes the properties of the online client id.
+func (s *ServerMethods) ClientEditContext(ctx context.Context, id int, props ...CmdArg) error {
+	args := append([]CmdArg{NewArg("clid", id)}, props...)
+	_, err := s.ExecCmdContext(ctx, NewCmd("clientedit").WithArgs(args...))
+	return err
+}
@@ -0,0 +1,173 @@
+package ts3
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCmdsClient(t *testing.T) {
+	s := newServer(t)
+	defer func() {
+		assert.NoError(t, s.Close())
+	}()
+
+	c, err := NewClient(s.Addr, Timeout(time.Second*2))
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	defer func() {
+		assert.NoError(t, c.Close())
+	}()
+
+	testCmdsClient(t, c, s)
+}
+
+func TestCmdsClientSSH(t *testing.T) {
+	s := newServer(t, useSSH())
+	defer func() {
+		assert.NoError(t, s.Close())
+	}()
+
+	c, err := NewClient(s.Addr, Timeout(time.Second*2), SSH(sshClientTestConfig))
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	defer func() {
+		assert.NoError(t, c.Close())
+	}()
+
+	testCmdsClient(t, c, s)
+}
+
+func testCmdsClient(t *testing.T, c *Client, s *server) {
+	t.Helper()
+	lastCmd := func() string {
+		received := s.Received()
+		return received[len(received)-1]
+	}
+
+	clientinfo := func(t *testing.T) {
+		t.Helper()
+		info, err := c.Server.ClientInfo(5)
+		if !assert.NoError(t, err) {
+			return
+		}
+
+		expected := &ClientDetails{
+			ChannelID:                      499,
+			IdleTime:                       28122,
+			UniqueIdentifier:               "P5H2hrN6+gpQI4n/dXp3p17vtY0=",
+			Nickname:                       "Rabe85",
+			Version:                        "3.0.0-alpha24 [Build: 8785] (UI: 8785)",
+			Platform:                       "Windows",
+			InputHardware:                  true,
+			OutputHardware:                 true,
+			VersionSign:                    "ldWL",
+			DatabaseID:                     2,
+			ChannelGroupID:                 5,
+			ServerGroups:                   []int{6, 8},
+			Created:                        time.Unix(1503929781, 0),
+			LastConnected:                  time.Unix(1503929781, 0),
+			TotalConnections:               14,
+			TalkPower:                      75,
+			Description:                    "Admin",
+			IsTalker:                       true,
+			NeededServerQueryViewPower:     75,
+			Country:                        "DE",
+			ChannelGroupInheritedChannelID: 499,
+			ClientConnection: ClientConnection{
+				PacketsSentTotal:            12130,
+				BytesSentTotal:              1213890,
+				PacketsReceivedTotal:        12112,
+				BytesReceivedTotal:          1185102,
+				BandwidthSentLastSecond:     81,
+				BandwidthSentLastMinute:     92,
+				BandwidthReceivedLastSecond: 83,
+				BandwidthReceivedLastMinute: 88,
+				ConnectedTime:               2403400,
+				IP:                          "127.0.0.1",
+			},
+		}
+		assert.Equal(t, expected, info)
+		assert.Equal(t, "clientinfo clid=5", lastCmd())
+	}
+
+	clientfind := func(t *testing.T) {
+		t.Helper()
+		clients, err := c.Server.ClientFind("Rabe")
+		if !assert.NoError(t, err) {
+			return
+		}
+
+		expected := []*OnlineClient{
+			{ID: 5, Nickname: "Rabe85"},
+			{ID: 6, Nickname: "Rabe86"},
+		}
+		assert.Equal(t, expected, clients)
+		assert.Equal(t, "clientfind pattern=Rabe", lastCmd())
+
+		clients, err = c.Server.ClientFind("missing")
+		assert.NoError(t, err)
+		assert.Empty(t, clients)
+	}
+
+	clientmove := func(t *testing.T) {
+		t.Helper()
+		assert.NoError(t, c.Server.ClientMove(499, "", 5, 6))
+		assert.Equal(t, "clientmove clid=5|clid=6 cid=499", lastCmd())
+
+		assert.NoError(t, c.Server.ClientMove(499, "secret pass", 5))
+		assert.Equal(t, `clientmove clid=5 cid=499 cpw=secret\spass`, lastCmd())
+
+		n := len(s.Received())
+		assert.Error(t, c.Server.ClientMove(499, ""))
+		assert.Len(t, s.Received(), n)
+	}
+
+	clientkick := func(t *testing.T) {
+		t.Helper()
+		assert.NoError(t, c.Server.ClientKick(ReasonServerKick, "bye", 5, 6))
+		assert.Equal(t, "clientkick clid=5|clid=6 reasonid=5 reasonmsg=bye", lastCmd())
+
+		assert.NoError(t, c.Server.ClientKick(ReasonChannelKick, "", 5))
+		assert.Equal(t, "clientkick clid=5 reasonid=4", lastCmd())
+
+		assert.Error(t, c.Server.ClientKick(ReasonBan, "", 5))
+
+		n := len(s.Received())
+		assert.Error(t, c.Server.ClientKick(ReasonServerKick, "bye"))
+		assert.Len(t, s.Received(), n)
+	}
+
+	clientpoke := func(t *testing.T) {
+		t.Helper()
+		assert.NoError(t, c.Server.ClientPoke(5, "wake up"))
+		assert.Equal(t, `clientpoke clid=5 msg=wake\sup`, lastCmd())
+	}
+
+	clientedit := func(t *testing.T) {
+		t.Helper()
+		assert.NoError(t, c.Server.ClientEdit(5, NewArg(ClientDescription, "the boss"), NewArg(ClientIsTalker, true)))
+		assert.Equal(t, `clientedit clid=5 client_description=the\sboss client_is_talker=1`, lastCmd())
+	}
+
+	tests := []struct {
+		name string
+		f    func(t *testing.T)
+	}{
+		{"clientinfo", clientinfo},
+		{"clientfind", clientfind},
+		{"clientmove", clientmove},
+		{"clientkick", clientkick},
+		{"clientpoke", clientpoke},
+		{"clientedit", clientedit},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, tc.f)
+	}
+}
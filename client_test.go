@@ -1,6 +1,7 @@
 package ts3
 
 import (
+	"context"
 	"errors"
 	"net"
 	"testing"
@@ -302,3 +303,66 @@ func TestClientBadHeader(t *testing.T) {
 	// Should never get here
 	assert.NoError(t, c.Close())
 }
+
+func TestClientContext(t *testing.T) {
+	s := newServer(t)
+	defer func() {
+		assert.NoError(t, s.Close())
+	}()
+
+	c, err := NewClient(s.Addr, Timeout(time.Second))
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	defer func() {
+		assert.NoError(t, c.Close())
+	}()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err = c.ExecContext(ctx, "version")
+	assert.Equal(t, context.Canceled, err)
+
+	ctx, cancel = context.WithTimeout(context.Background(), delayDuration/4)
+	defer cancel()
+	_, err = c.ExecContext(ctx, cmdDelay)
+	assert.Equal(t, context.DeadlineExceeded, err)
+
+	// The response to the abandoned command must not be returned here.
+	v, err := c.VersionContext(context.Background())
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.Equal(t, "3.0.12.2", v.Version)
+}
+
+func TestClientTimeoutQueued(t *testing.T) {
+	s := newServer(t)
+	defer func() {
+		assert.NoError(t, s.Close())
+	}()
+
+	c, err := NewClient(s.Addr, Timeout(delayDuration*3/2))
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	defer func() {
+		assert.NoError(t, c.Close())
+	}()
+
+	// The second command waits for the first before it's sent, which
+	// mustn't count towards its timeout.
+	errs := make(chan error, 2)
+	for i := 0; i < 2; i++ {
+		go func() {
+			_, err := c.Exec(cmdDelay)
+			errs <- err
+		}()
+	}
+
+	for i := 0; i < 2; i++ {
+		assert.NoError(t, <-errs)
+	}
+}
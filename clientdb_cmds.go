@@ -0,0 +1,247 @@
+package ts3
+
+import (
+	"context"
+	"time"
+)
+
+// DefaultClientDBPageSize is the default number of client identities
+// requested per page by ClientDBIter.
+var DefaultClientDBPageSize = 200
+
+// DBClient represents a client identity on a virtual server.
+type DBClient struct {
+	ID               int       `ms:"cldbid"`
+	UniqueIdentifier string    `ms:"client_unique_identifier"`
+	Nickname         string    `ms:"client_nickname"`
+	Created          time.Time `ms:"client_created"`
+	LastConnected    time.Time `ms:"client_lastconnected"`
+	Connections      int       `ms:"client_totalconnections"`
+	Description      string    `ms:"client_description"`
+	LastIP           string    `ms:"client_lastip"`
+	LoginName        string    `ms:"client_login_name"`
+}
+
+// ClientDBList returns a list of client identities known by the server.
+// Only the first page is returned, use ClientDBIter to retrieve them all.
+func (s *ServerMethods) ClientDBList() ([]*DBClient, error) {
+	return s.ClientDBListContext(context.Background())
+}
+
+// ClientDBListContext returns a list of client identities known by the server.
+func (s *ServerMethods) ClientDBListContext(ctx context.Context) ([]*DBClient, error) {
+	var dbclients []*DBClient
+	if _, err := s.ExecCmdContext(ctx, NewCmd("clientdblist").WithResponse(&dbclients)); err != nil {
+		return nil, err
+	}
+	return dbclients, nil
+}
+
+// ClientDBListPage returns up to duration client identities known by the
+// server starting at offset start.
+func (s *ServerMethods) ClientDBListPage(start, duration int) ([]*DBClient, error) {
+	return s.ClientDBListPageContext(context.Background(), start, duration)
+}
+
+// ClientDBListPageContext returns up to duration client identities known by the
+// server starting at offset start.
+func (s *ServerMethods) ClientDBListPageContext(ctx context.Context, start, duration int) ([]*DBClient, error) {
+	var dbclients []*DBClient
+	if _, err := s.ExecCmdContext(ctx, NewCmd("clientdblist").WithArgs(
+		NewArg("start", start),
+		NewArg("duration", duration),
+	).WithResponse(&dbclients)); err != nil {
+		if isEmptyResult(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return dbclients, nil
+}
+
+// ClientDBCount returns the number of client identities known by the server.
+func (s *ServerMethods) ClientDBCount() (int, error) {
+	return s.ClientDBCountContext(context.Background())
+}
+
+// ClientDBCountContext returns the number of client identities known by the server.
+func (s *ServerMethods) ClientDBCountContext(ctx context.Context) (int, error) {
+	var r []struct {
+		Count int `ms:"count"`
+	}
+	if _, err := s.ExecCmdContext(ctx, NewCmd("clientdblist").WithArgs(
+		NewArg("start", 0),
+		NewArg("duration", 1),
+	).WithOptions("-count").WithResponse(&r)); err != nil {
+		if isEmptyResult(err) {
+			return 0, nil
+		}
+		return 0, err
+	}
+
+	if len(r) == 0 {
+		return 0, nil
+	}
+
+	return r[0].Count, nil
+}
+
+// DBClientIterator iterates over all client identities known by the server,
+// requesting them a page at a time.
+type DBClientIterator struct {
+	s        *ServerMethods
+	ctx      context.Context
+	pageSize int
+	start    int
+	page     []*DBClient
+	client   *DBClient
+	done     bool
+	err      error
+}
+
+// ClientDBIter returns an iterator over all client identities known by the
+// server which requests pageSize identities at a time. If pageSize is less
+// than 1 DefaultClientDBPageSize is used.
+func (s *ServerMethods) ClientDBIter(pageSize int) *DBClientIterator {
+	return s.ClientDBIterContext(context.Background(), pageSize)
+}
+
+// ClientDBIterContext returns an iterator over all client identities known by the
+// server which requests pageSize identities at a time.
+func (s *ServerMethods) ClientDBIterContext(ctx context.Context, pageSize int) *DBClientIterator {
+	if pageSize < 1 {
+		pageSize = DefaultClientDBPageSize
+	}
+
+	return &DBClientIterator{s: s, ctx: ctx, pageSize: pageSize}
+}
+
+// Next advances the iterator to the next client identity, which is then
+// available from Client. It returns false when there are no more
+// identities or an error occurred, which is then available from Err.
+func (it *DBClientIterator) Next() bool {
+	if len(it.page) == 0 {
+		if it.done {
+			it.client = nil
+			return false
+		}
+
+		it.page, it.err = it.s.ClientDBListPageContext(it.ctx, it.start, it.pageSize)
+		if it.err != nil || len(it.page) == 0 {
+			it.done = true
+			it.client = nil
+			return false
+		}
+
+		it.start += len(it.page)
+		it.done = len(it.page) < it.pageSize
+	}
+
+	it.client = it.page[0]
+	it.page = it.page[1:]
+
+	return true
+}
+
+// Client returns the current client identity.
+func (it *DBClientIterator) Client() *DBClient {
+	return it.client
+}
+
+// Err returns the error, if any, which stopped the iteration.
+func (it *DBClientIterator) Err() error {
+	return it.err
+}
+
+// DBClientInfo represents detailed information about a client identity.
+type DBClientInfo struct {
+	UniqueIdentifier     string    `ms:"client_unique_identifier"`
+	Nickname             string    `ms:"client_nickname"`
+	DatabaseID           int       `ms:"client_database_id"`
+	Created              time.Time `ms:"client_created"`
+	LastConnected        time.Time `ms:"client_lastconnected"`
+	TotalConnections     int       `ms:"client_totalconnections"`
+	FlagAvatar           string    `ms:"client_flag_avatar"`
+	Description          string    `ms:"client_description"`
+	MonthBytesUploaded   uint64    `ms:"client_month_bytes_uploaded"`
+	MonthBytesDownloaded uint64    `ms:"client_month_bytes_downloaded"`
+	TotalBytesUploaded   uint64    `ms:"client_total_bytes_uploaded"`
+	TotalBytesDownloaded uint64    `ms:"client_total_bytes_downloaded"`
+	Base64HashClientUID  string    `ms:"client_base64HashClientUID"`
+	LastIP               string    `ms:"client_lastip"`
+}
+
+// ClientDBInfo returns detailed information about the client identity with
+// database ID dbID.
+func (s *ServerMethods) ClientDBInfo(dbID int) (*DBClientInfo, error) {
+	return s.ClientDBInfoContext(context.Background(), dbID)
+}
+
+// ClientDBInfoContext returns detailed information about the client identity with
+// database ID dbID.
+func (s *ServerMethods) ClientDBInfoContext(ctx context.Context, dbID int) (*DBClientInfo, error) {
+	info := &DBClientInfo{}
+	if _, err := s.ExecCmdContext(ctx, NewCmd("clientdbinfo").WithArgs(NewArg("cldbid", dbID)).WithResponse(info)); err != nil {
+		return nil, err
+	}
+
+	return info, nil
+}
+
+// ClientDBFind returns the database IDs of the client identities whose
+// nickname matches pattern or, if uid is true, whose unique identifier
+// matches pattern.
+func (s *ServerMethods) ClientDBFind(pattern string, uid bool) ([]int, error) {
+	return s.ClientDBFindContext(context.Background(), pattern, uid)
+}
+
+// ClientDBFindContext returns the database IDs of the client identities whose
+// nickname or unique identifier matches pattern.
+func (s *ServerMethods) ClientDBFindContext(ctx context.Context, pattern string, uid bool) ([]int, error) {
+	cmd := NewCmd("clientdbfind").WithArgs(NewArg("pattern", pattern))
+	if uid {
+		cmd.WithOptions("-uid")
+	}
+
+	var r []struct {
+		ID int `ms:"cldbid"`
+	}
+	if _, err := s.ExecCmdContext(ctx, cmd.WithResponse(&r)); err != nil {
+		if isEmptyResult(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	ids := make([]int, len(r))
+	for i, v := range r {
+		ids[i] = v.ID
+	}
+
+	return ids, nil
+}
+
+// ClientDBEdit changes the properties of the client identity with database
+// ID dbID e.g. NewArg(ClientDescription, "text").
+func (s *ServerMethods) ClientDBEdit(dbID int, props ...CmdArg) error {
+	return s.ClientDBEditContext(context.Background(), dbID, props...)
+}
+
+// ClientDBEditContext changes the properties of the client identity with database
+// ID dbID.
+func (s *ServerMethods) ClientDBEditContext(ctx context.Context, dbID int, props ...CmdArg) error {
+	args := append([]CmdArg{NewArg("cldbid", dbID)}, props...)
+	_, err := s.ExecCmdContext(ctx, NewCmd("clientdbedit").WithArgs(args...))
+	return err
+}
+
+// ClientDBDelete deletes the client identity with database ID dbID.
+func (s *ServerMethods) ClientDBDelete(dbID int) error {
+	return s.ClientDBDeleteContext(context.Background(), dbID)
+}
+
+// ClientDBDeleteContext deletes the client identity with database ID dbID.
+func (s *ServerMethods) ClientDBDeleteContext(ctx context.Context, dbID int) error {
+	_, err := s.ExecCmdContext(ctx, NewCmd("clientdbdelete").WithArgs(NewArg("cldbid", dbID)))
+	return err
+}
@@ -0,0 +1,196 @@
+package ts3
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCmdsClientDB(t *testing.T) {
+	s := newServer(t)
+	defer func() {
+		assert.NoError(t, s.Close())
+	}()
+
+	c, err := NewClient(s.Addr, Timeout(time.Second*2))
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	defer func() {
+		assert.NoError(t, c.Close())
+	}()
+
+	testCmdsClientDB(t, c, s)
+}
+
+func TestCmdsClientDBSSH(t *testing.T) {
+	s := newServer(t, useSSH())
+	defer func() {
+		assert.NoError(t, s.Close())
+	}()
+
+	c, err := NewClient(s.Addr, Timeout(time.Second*2), SSH(sshClientTestConfig))
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	defer func() {
+		assert.NoError(t, c.Close())
+	}()
+
+	testCmdsClientDB(t, c, s)
+}
+
+func testCmdsClientDB(t *testing.T, c *Client, s *server) {
+	t.Helper()
+	lastCmd := func() string {
+		received := s.Received()
+		return received[len(received)-1]
+	}
+
+	clientdblistpage := func(t *testing.T) {
+		t.Helper()
+		list, err := c.Server.ClientDBListPage(0, 2)
+		if !assert.NoError(t, err) {
+			return
+		}
+
+		expected := []*DBClient{
+			{
+				ID:               1,
+				UniqueIdentifier: "a=",
+				Nickname:         "one",
+				Created:          time.Unix(1259147468, 0),
+				LastConnected:    time.Unix(1259421233, 0),
+				Connections:      1,
+				LastIP:           "1.2.3.4",
+			},
+			{
+				ID:               2,
+				UniqueIdentifier: "b=",
+				Nickname:         "two",
+				Created:          time.Unix(1259147468, 0),
+				LastConnected:    time.Unix(1259421233, 0),
+				Connections:      2,
+			},
+		}
+		assert.Equal(t, expected, list)
+
+		list, err = c.Server.ClientDBListPage(3, 3)
+		assert.NoError(t, err)
+		assert.Empty(t, list)
+	}
+
+	clientdbcount := func(t *testing.T) {
+		t.Helper()
+		n, err := c.Server.ClientDBCount()
+		if !assert.NoError(t, err) {
+			return
+		}
+		assert.Equal(t, 3, n)
+	}
+
+	iterate := func(t *testing.T, pageSize int) ([]int, error) {
+		t.Helper()
+		var ids []int
+		it := c.Server.ClientDBIter(pageSize)
+		for it.Next() {
+			ids = append(ids, it.Client().ID)
+		}
+		assert.False(t, it.Next())
+		assert.Nil(t, it.Client())
+		return ids, it.Err()
+	}
+
+	clientdbiter := func(t *testing.T) {
+		t.Helper()
+		// Short last page.
+		ids, err := iterate(t, 2)
+		require.NoError(t, err)
+		assert.Equal(t, []int{1, 2, 3}, ids)
+
+		// Empty last page.
+		ids, err = iterate(t, 3)
+		require.NoError(t, err)
+		assert.Equal(t, []int{1, 2, 3}, ids)
+		assert.Equal(t, "clientdblist start=3 duration=3", lastCmd())
+
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		it := c.Server.ClientDBIterContext(ctx, 2)
+		assert.False(t, it.Next())
+		assert.Equal(t, context.Canceled, it.Err())
+	}
+
+	clientdbinfo := func(t *testing.T) {
+		t.Helper()
+		info, err := c.Server.ClientDBInfo(7)
+		if !assert.NoError(t, err) {
+			return
+		}
+
+		expected := &DBClientInfo{
+			UniqueIdentifier:     "DZhdQU58qyooEK4Fr8Ly738hEmc=",
+			Nickname:             "MuhChy",
+			DatabaseID:           7,
+			Created:              time.Unix(1259147468, 0),
+			LastConnected:        time.Unix(1259421233, 0),
+			TotalConnections:     5,
+			Description:          "Admin",
+			MonthBytesUploaded:   10,
+			MonthBytesDownloaded: 20,
+			TotalBytesUploaded:   30,
+			TotalBytesDownloaded: 40,
+			Base64HashClientUID:  "jneilbgomklpfnkjclkoggokfdmdlhnbbpmdpagh",
+			LastIP:               "1.3.3.7",
+		}
+		assert.Equal(t, expected, info)
+	}
+
+	clientdbfind := func(t *testing.T) {
+		t.Helper()
+		ids, err := c.Server.ClientDBFind("DZhdQU58qyooEK4Fr8Ly738hEmc=", true)
+		if !assert.NoError(t, err) {
+			return
+		}
+		assert.Equal(t, []int{7, 9}, ids)
+		assert.Equal(t, "clientdbfind pattern=DZhdQU58qyooEK4Fr8Ly738hEmc= -uid", lastCmd())
+
+		_, err = c.Server.ClientDBFind("Muh", false)
+		assert.NoError(t, err)
+		assert.Equal(t, "clientdbfind pattern=Muh", lastCmd())
+	}
+
+	clientdbedit := func(t *testing.T) {
+		t.Helper()
+		assert.NoError(t, c.Server.ClientDBEdit(7, NewArg(ClientDescription, "new desc")))
+		assert.Equal(t, `clientdbedit cldbid=7 client_description=new\sdesc`, lastCmd())
+	}
+
+	clientdbdelete := func(t *testing.T) {
+		t.Helper()
+		assert.NoError(t, c.Server.ClientDBDelete(7))
+		assert.Equal(t, "clientdbdelete cldbid=7", lastCmd())
+	}
+
+	tests := []struct {
+		name string
+		f    func(t *testing.T)
+	}{
+		{"clientdblistpage", clientdblistpage},
+		{"clientdbcount", clientdbcount},
+		{"clientdbiter", clientdbiter},
+		{"clientdbinfo", clientdbinfo},
+		{"clientdbfind", clientdbfind},
+		{"clientdbedit", clientdbedit},
+		{"clientdbdelete", clientdbdelete},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, tc.f)
+	}
+}
@@ -0,0 +1,264 @@
+package ts3
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// IDCache enables caching of the results of the client ID resolution
+// methods, such as ClientGetDBIDFromUID, for ttl. This reduces the number of
+// commands sent, and hence flood points used, when resolving the same
+// clients repeatedly.
+//
+// Cached values may be stale by up to ttl, which is most noticeable for
+// ClientGetIDs and ClientGetUIDFromCLID as they depend on who's online.
+// The cache is cleared when Use or UsePort select a virtual server and
+// when the connection is restored, as IDs are specific to a server.
+func IDCache(ttl time.Duration) func(*Client) error {
+	return func(c *Client) error {
+		if ttl <= 0 {
+			return fmt.Errorf("id cache: invalid ttl %v", ttl)
+		}
+		c.ids = newIDCache(ttl)
+		return nil
+	}
+}
+
+// idCacheKey identifies a cached lookup.
+type idCacheKey struct {
+	cmd string
+	arg string
+}
+
+// idCacheEntry is a cached lookup result.
+type idCacheEntry struct {
+	value   interface{}
+	expires time.Time
+}
+
+// idCache is a cache of client ID resolution results.
+// The IDs it holds belong to the selected virtual server so it's reset when
+// a different server is selected or the connection is restored.
+type idCache struct {
+	mtx       sync.Mutex
+	ttl       time.Duration
+	now       func() time.Time
+	nextSweep time.Time
+	entries   map[idCacheKey]idCacheEntry
+}
+
+// newIDCache returns a new idCache whose entries expire after ttl.
+func newIDCache(ttl time.Duration) *idCache {
+	return &idCache{
+		ttl:     ttl,
+		now:     time.Now,
+		entries: make(map[idCacheKey]idCacheEntry),
+	}
+}
+
+// get returns the unexpired value for key if present.
+// It's safe to call on a nil cache.
+func (c *idCache) get(key idCacheKey) (interface{}, bool) {
+	if c == nil {
+		return nil, false
+	}
+
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	e, ok := c.entries[key]
+	if !ok {
+		return nil, false
+	}
+
+	if !c.now().Before(e.expires) {
+		delete(c.entries, key)
+		return nil, false
+	}
+
+	return e.value, true
+}
+
+// set stores value for key.
+// It's safe to call on a nil cache.
+func (c *idCache) set(key idCacheKey, value interface{}) {
+	if c == nil {
+		return
+	}
+
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	now := c.now()
+	c.sweep(now)
+	c.entries[key] = idCacheEntry{value: value, expires: now.Add(c.ttl)}
+}
+
+// sweep removes expired entries, at most once per ttl, so entries which
+// are never looked up again don't accumulate.
+// Callers must hold c.mtx.
+func (c *idCache) sweep(now time.Time) {
+	if now.Before(c.nextSweep) {
+		return
+	}
+
+	for k, e := range c.entries {
+		if !now.Before(e.expires) {
+			delete(c.entries, k)
+		}
+	}
+	c.nextSweep = now.Add(c.ttl)
+}
+
+// reset removes all entries.
+// It's safe to call on a nil cache.
+func (c *idCache) reset() {
+	if c == nil {
+		return
+	}
+
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	c.entries = make(map[idCacheKey]idCacheEntry)
+}
+
+// ResetIDCache clears the cache enabled by IDCache.
+func (s *ServerMethods) ResetIDCache() {
+	s.ids.reset()
+}
+
+// ClientGetIDs returns the IDs of the online clients with the unique identifier uid.
+func (s *ServerMethods) ClientGetIDs(uid string) ([]int, error) {
+	return s.ClientGetIDsContext(context.Background(), uid)
+}
+
+// ClientGetIDsContext returns the IDs of the online clients with the unique identifier uid.
+func (s *ServerMethods) ClientGetIDsContext(ctx context.Context, uid string) ([]int, error) {
+	key := idCacheKey{cmd: "clientgetids", arg: uid}
+	if v, ok := s.ids.get(key); ok {
+		return append([]int(nil), v.([]int)...), nil
+	}
+
+	var r []*struct {
+		ID int `ms:"clid"`
+	}
+	if _, err := s.ExecCmdContext(ctx, NewCmd("clientgetids").WithArgs(NewArg("cluid", uid)).WithResponse(&r)); err != nil {
+		return nil, err
+	}
+
+	ids := make([]int, len(r))
+	for i, c := range r {
+		ids[i] = c.ID
+	}
+	s.ids.set(key, append([]int(nil), ids...))
+
+	return ids, nil
+}
+
+// ClientGetDBIDFromUID returns the database ID of the client with the unique identifier uid.
+func (s *ServerMethods) ClientGetDBIDFromUID(uid string) (int, error) {
+	return s.ClientGetDBIDFromUIDContext(context.Background(), uid)
+}
+
+// ClientGetDBIDFromUIDContext returns the database ID of the client with the unique identifier uid.
+func (s *ServerMethods) ClientGetDBIDFromUIDContext(ctx context.Context, uid string) (int, error) {
+	key := idCacheKey{cmd: "clientgetdbidfromuid", arg: uid}
+	if v, ok := s.ids.get(key); ok {
+		return v.(int), nil
+	}
+
+	r := struct {
+		DatabaseID int `ms:"cldbid"`
+	}{}
+	if _, err := s.ExecCmdContext(ctx, NewCmd("clientgetdbidfromuid").WithArgs(NewArg("cluid", uid)).WithResponse(&r)); err != nil {
+		return 0, err
+	}
+
+	s.ids.set(key, r.DatabaseID)
+
+	return r.DatabaseID, nil
+}
+
+// clientName is the response to clientgetnamefromuid and clientgetnamefromdbid.
+type clientName struct {
+	UniqueIdentifier string `ms:"cluid"`
+	DatabaseID       int    `ms:"cldbid"`
+	Name             string `ms:"name"`
+}
+
+// setClientName caches all the lookups answered by n.
+func (s *ServerMethods) setClientName(n *clientName) {
+	s.ids.set(idCacheKey{cmd: "clientgetdbidfromuid", arg: n.UniqueIdentifier}, n.DatabaseID)
+	s.ids.set(idCacheKey{cmd: "clientgetnamefromuid", arg: n.UniqueIdentifier}, n.Name)
+	s.ids.set(idCacheKey{cmd: "clientgetnamefromdbid", arg: strconv.Itoa(n.DatabaseID)}, n.Name)
+}
+
+// ClientGetNameFromUID returns the last known nickname of the client with the unique identifier uid.
+func (s *ServerMethods) ClientGetNameFromUID(uid string) (string, error) {
+	return s.ClientGetNameFromUIDContext(context.Background(), uid)
+}
+
+// ClientGetNameFromUIDContext returns the last known nickname of the client with the unique identifier uid.
+func (s *ServerMethods) ClientGetNameFromUIDContext(ctx context.Context, uid string) (string, error) {
+	if v, ok := s.ids.get(idCacheKey{cmd: "clientgetnamefromuid", arg: uid}); ok {
+		return v.(string), nil
+	}
+
+	n := &clientName{}
+	if _, err := s.ExecCmdContext(ctx, NewCmd("clientgetnamefromuid").WithArgs(NewArg("cluid", uid)).WithResponse(n)); err != nil {
+		return "", err
+	}
+
+	s.setClientName(n)
+
+	return n.Name, nil
+}
+
+// ClientGetNameFromDBID returns the last known nickname of the client with the database ID dbID.
+func (s *ServerMethods) ClientGetNameFromDBID(dbID int) (string, error) {
+	return s.ClientGetNameFromDBIDContext(context.Background(), dbID)
+}
+
+// ClientGetNameFromDBIDContext returns the last known nickname of the client with the database ID dbID.
+func (s *ServerMethods) ClientGetNameFromDBIDContext(ctx context.Context, dbID int) (string, error) {
+	if v, ok := s.ids.get(idCacheKey{cmd: "clientgetnamefromdbid", arg: strconv.Itoa(dbID)}); ok {
+		return v.(string), nil
+	}
+
+	n := &clientName{}
+	if _, err := s.ExecCmdContext(ctx, NewCmd("clientgetnamefromdbid").WithArgs(NewArg("cldbid", dbID)).WithResponse(n)); err != nil {
+		return "", err
+	}
+
+	s.setClientName(n)
+
+	return n.Name, nil
+}
+
+// ClientGetUIDFromCLID returns the unique identifier of the online client id.
+func (s *ServerMethods) ClientGetUIDFromCLID(id int) (string, error) {
+	return s.ClientGetUIDFromCLIDContext(context.Background(), id)
+}
+
+// ClientGetUIDFromCLIDContext returns the unique identifier of the online client id.
+func (s *ServerMethods) ClientGetUIDFromCLIDContext(ctx context.Context, id int) (string, error) {
+	key := idCacheKey{cmd: "clientgetuidfromclid", arg: strconv.Itoa(id)}
+	if v, ok := s.ids.get(key); ok {
+		return v.(string), nil
+	}
+
+	r := struct {
+		UniqueIdentifier string `ms:"cluid"`
+	}{}
+	if _, err := s.ExecCmdContext(ctx, NewCmd("clientgetuidfromclid").WithArgs(NewArg("clid", id)).WithResponse(&r)); err != nil {
+		return "", err
+	}
+
+	s.ids.set(key, r.UniqueIdentifier)
+
+	return r.UniqueIdentifier, nil
+}
@@ -0,0 +1,191 @@
+package ts3
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCmdsClientID(t *testing.T) {
+	s := newServer(t)
+	defer func() {
+		assert.NoError(t, s.Close())
+	}()
+
+	c, err := NewClient(s.Addr, Timeout(time.Second*2))
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	defer func() {
+		assert.NoError(t, c.Close())
+	}()
+
+	testCmdsClientID(t, c, s)
+}
+
+func TestCmdsClientIDSSH(t *testing.T) {
+	s := newServer(t, useSSH())
+	defer func() {
+		assert.NoError(t, s.Close())
+	}()
+
+	c, err := NewClient(s.Addr, Timeout(time.Second*2), SSH(sshClientTestConfig))
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	defer func() {
+		assert.NoError(t, c.Close())
+	}()
+
+	testCmdsClientID(t, c, s)
+}
+
+func testCmdsClientID(t *testing.T, c *Client, s *server) {
+	t.Helper()
+	const uid = "dyjxkshZP6bz0n3bnwFQ1CkwZOM="
+	lastCmd := func() string {
+		received := s.Received()
+		return received[len(received)-1]
+	}
+
+	clientgetids := func(t *testing.T) {
+		t.Helper()
+		ids, err := c.Server.ClientGetIDs(uid)
+		if !assert.NoError(t, err) {
+			return
+		}
+		assert.Equal(t, []int{1, 3}, ids)
+		assert.Equal(t, "clientgetids cluid="+uid, lastCmd())
+	}
+
+	clientgetdbidfromuid := func(t *testing.T) {
+		t.Helper()
+		id, err := c.Server.ClientGetDBIDFromUID(uid)
+		if !assert.NoError(t, err) {
+			return
+		}
+		assert.Equal(t, 32, id)
+		assert.Equal(t, "clientgetdbidfromuid cluid="+uid, lastCmd())
+	}
+
+	clientgetnamefromuid := func(t *testing.T) {
+		t.Helper()
+		name, err := c.Server.ClientGetNameFromUID(uid)
+		if !assert.NoError(t, err) {
+			return
+		}
+		assert.Equal(t, "Janko", name)
+		assert.Equal(t, "clientgetnamefromuid cluid="+uid, lastCmd())
+	}
+
+	clientgetnamefromdbid := func(t *testing.T) {
+		t.Helper()
+		name, err := c.Server.ClientGetNameFromDBID(32)
+		if !assert.NoError(t, err) {
+			return
+		}
+		assert.Equal(t, "Janko", name)
+		assert.Equal(t, "clientgetnamefromdbid cldbid=32", lastCmd())
+	}
+
+	clientgetuidfromclid := func(t *testing.T) {
+		t.Helper()
+		got, err := c.Server.ClientGetUIDFromCLID(1)
+		if !assert.NoError(t, err) {
+			return
+		}
+		assert.Equal(t, uid, got)
+		assert.Equal(t, "clientgetuidfromclid clid=1", lastCmd())
+	}
+
+	tests := []struct {
+		name string
+		f    func(t *testing.T)
+	}{
+		{"clientgetids", clientgetids},
+		{"clientgetdbidfromuid", clientgetdbidfromuid},
+		{"clientgetnamefromuid", clientgetnamefromuid},
+		{"clientgetnamefromdbid", clientgetnamefromdbid},
+		{"clientgetuidfromclid", clientgetuidfromclid},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, tc.f)
+	}
+}
+
+func TestIDCache(t *testing.T) {
+	_, err := NewClient("127.0.0.1:0", IDCache(0))
+	assert.Error(t, err)
+
+	s := newServer(t)
+	defer func() {
+		assert.NoError(t, s.Close())
+	}()
+
+	c, err := NewClient(s.Addr, Timeout(time.Second*2), IDCache(time.Minute))
+	require.NoError(t, err)
+
+	defer func() {
+		assert.NoError(t, c.Close())
+	}()
+
+	now := time.Now()
+	c.ids.now = func() time.Time { return now }
+
+	const uid = "dyjxkshZP6bz0n3bnwFQ1CkwZOM="
+	ids, err := c.Server.ClientGetIDs(uid)
+	require.NoError(t, err)
+	ids[0] = 99 // Must not modify the cached value.
+	sent := len(s.Received())
+
+	ids, err = c.Server.ClientGetIDs(uid)
+	require.NoError(t, err)
+	assert.Equal(t, []int{1, 3}, ids)
+	assert.Len(t, s.Received(), sent)
+
+	// Name from uid also answers the other lookups.
+	name, err := c.Server.ClientGetNameFromUID(uid)
+	require.NoError(t, err)
+	assert.Equal(t, "Janko", name)
+	sent = len(s.Received())
+
+	id, err := c.Server.ClientGetDBIDFromUID(uid)
+	require.NoError(t, err)
+	assert.Equal(t, 32, id)
+	name, err = c.Server.ClientGetNameFromDBID(32)
+	require.NoError(t, err)
+	assert.Equal(t, "Janko", name)
+	assert.Len(t, s.Received(), sent)
+
+	// Expired.
+	now = now.Add(time.Minute)
+	_, err = c.Server.ClientGetDBIDFromUID(uid)
+	require.NoError(t, err)
+	assert.Len(t, s.Received(), sent+1)
+
+	c.Server.ResetIDCache()
+	_, err = c.Server.ClientGetDBIDFromUID(uid)
+	require.NoError(t, err)
+	assert.Len(t, s.Received(), sent+2)
+
+	// Selecting a server clears the cache.
+	require.NoError(t, c.Use(1))
+	sent = len(s.Received())
+	_, err = c.Server.ClientGetDBIDFromUID(uid)
+	require.NoError(t, err)
+	assert.Len(t, s.Received(), sent+1)
+
+	// Expired entries are swept when new ones are added.
+	now = now.Add(time.Minute)
+	key := idCacheKey{cmd: "test", arg: "new"}
+	c.ids.set(key, 1)
+	c.ids.mtx.Lock()
+	assert.Len(t, c.ids.entries, 1)
+	assert.Contains(t, c.ids.entries, key)
+	c.ids.mtx.Unlock()
+}
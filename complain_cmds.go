@@ -0,0 +1,79 @@
+package ts3
+
+import (
+	"context"
+	"time"
+)
+
+// Complaint represents a complaint about a client.
+type Complaint struct {
+	TargetDatabaseID int       `ms:"tcldbid"`
+	TargetName       string    `ms:"tname"`
+	FromDatabaseID   int       `ms:"fcldbid"`
+	FromName         string    `ms:"fname"`
+	Message          string    `ms:"message"`
+	Created          time.Time `ms:"timestamp"`
+}
+
+// ComplainList returns the complaints on the selected server.
+// If targetDBID isn't 0 only complaints about that client are returned.
+func (s *ServerMethods) ComplainList(targetDBID int) ([]*Complaint, error) {
+	return s.ComplainListContext(context.Background(), targetDBID)
+}
+
+// ComplainListContext returns the complaints on the selected server.
+func (s *ServerMethods) ComplainListContext(ctx context.Context, targetDBID int) ([]*Complaint, error) {
+	cmd := NewCmd("complainlist")
+	if targetDBID != 0 {
+		cmd.WithArgs(NewArg("tcldbid", targetDBID))
+	}
+
+	var complaints []*Complaint
+	if _, err := s.ExecCmdContext(ctx, cmd.WithResponse(&complaints)); err != nil {
+		if isEmptyResult(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return complaints, nil
+}
+
+// ComplainAdd submits a complaint about the client targetDBID.
+func (s *ServerMethods) ComplainAdd(targetDBID int, msg string) error {
+	return s.ComplainAddContext(context.Background(), targetDBID, msg)
+}
+
+// ComplainAddContext submits a complaint about the client targetDBID.
+func (s *ServerMethods) ComplainAddContext(ctx context.Context, targetDBID int, msg string) error {
+	_, err := s.ExecCmdContext(ctx, NewCmd("complainadd").WithArgs(
+		NewArg("tcldbid", targetDBID),
+		NewArg("message", msg),
+	))
+	return err
+}
+
+// ComplainDel deletes the complaint about the client targetDBID made by fromDBID.
+func (s *ServerMethods) ComplainDel(targetDBID, fromDBID int) error {
+	return s.ComplainDelContext(context.Background(), targetDBID, fromDBID)
+}
+
+// ComplainDelContext deletes the complaint about the client targetDBID made by fromDBID.
+func (s *ServerMethods) ComplainDelContext(ctx context.Context, targetDBID, fromDBID int) error {
+	_, err := s.ExecCmdContext(ctx, NewCmd("complaindel").WithArgs(
+		NewArg("tcldbid", targetDBID),
+		NewArg("fcldbid", fromDBID),
+	))
+	return err
+}
+
+// ComplainDelAll deletes all the complaints about the client targetDBID.
+func (s *ServerMethods) ComplainDelAll(targetDBID int) error {
+	return s.ComplainDelAllContext(context.Background(), targetDBID)
+}
+
+// ComplainDelAllContext deletes all the complaints about the client targetDBID.
+func (s *ServerMethods) ComplainDelAllContext(ctx context.Context, targetDBID int) error {
+	_, err := s.ExecCmdContext(ctx, NewCmd("complaindelall").WithArgs(NewArg("tcldbid", targetDBID)))
+	return err
+}
@@ -0,0 +1,124 @@
+package ts3
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCmdsComplain(t *testing.T) {
+	s := newServer(t)
+	defer func() {
+		assert.NoError(t, s.Close())
+	}()
+
+	c, err := NewClient(s.Addr, Timeout(time.Second*2))
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	defer func() {
+		assert.NoError(t, c.Close())
+	}()
+
+	testCmdsComplain(t, c, s)
+}
+
+func TestCmdsComplainSSH(t *testing.T) {
+	s := newServer(t, useSSH())
+	defer func() {
+		assert.NoError(t, s.Close())
+	}()
+
+	c, err := NewClient(s.Addr, Timeout(time.Second*2), SSH(sshClientTestConfig))
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	defer func() {
+		assert.NoError(t, c.Close())
+	}()
+
+	testCmdsComplain(t, c, s)
+}
+
+func testCmdsComplain(t *testing.T, c *Client, s *server) {
+	t.Helper()
+	lastCmd := func() string {
+		received := s.Received()
+		return received[len(received)-1]
+	}
+
+	complainlist := func(t *testing.T) {
+		t.Helper()
+		complaints, err := c.Server.ComplainList(0)
+		if !assert.NoError(t, err) {
+			return
+		}
+
+		first := &Complaint{
+			TargetDatabaseID: 3,
+			TargetName:       "Julian",
+			FromDatabaseID:   4,
+			FromName:         "Sven",
+			Message:          "Bad guy",
+			Created:          time.Unix(1259147468, 0),
+		}
+		expected := []*Complaint{
+			first,
+			{
+				TargetDatabaseID: 5,
+				TargetName:       "Bob",
+				FromDatabaseID:   4,
+				FromName:         "Sven",
+				Message:          "Spam",
+				Created:          time.Unix(1259421233, 0),
+			},
+		}
+		assert.Equal(t, expected, complaints)
+		assert.Equal(t, "complainlist", lastCmd())
+
+		complaints, err = c.Server.ComplainList(3)
+		if !assert.NoError(t, err) {
+			return
+		}
+		assert.Equal(t, []*Complaint{first}, complaints)
+
+		complaints, err = c.Server.ComplainList(9)
+		assert.NoError(t, err)
+		assert.Empty(t, complaints)
+	}
+
+	complainadd := func(t *testing.T) {
+		t.Helper()
+		assert.NoError(t, c.Server.ComplainAdd(3, "Bad guy"))
+		assert.Equal(t, `complainadd tcldbid=3 message=Bad\sguy`, lastCmd())
+	}
+
+	complaindel := func(t *testing.T) {
+		t.Helper()
+		assert.NoError(t, c.Server.ComplainDel(3, 4))
+		assert.Equal(t, "complaindel tcldbid=3 fcldbid=4", lastCmd())
+	}
+
+	complaindelall := func(t *testing.T) {
+		t.Helper()
+		assert.NoError(t, c.Server.ComplainDelAll(3))
+		assert.Equal(t, "complaindelall tcldbid=3", lastCmd())
+	}
+
+	tests := []struct {
+		name string
+		f    func(t *testing.T)
+	}{
+		{"complainlist", complainlist},
+		{"complainadd", complainadd},
+		{"complaindel", complaindel},
+		{"complaindelall", complaindelall},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, tc.f)
+	}
+}
@@ -0,0 +1,341 @@
+package ts3
+
+import (
+	"fmt"
+	"sync"
+)
+
+// DefaultEventWorkers is the default number of workers used to run event handlers.
+var DefaultEventWorkers = 4
+
+// EventWorkers sets the number of workers used to run event handlers
+// registered with Subscribe and the On methods.
+func EventWorkers(n int) func(*Client) error {
+	return func(c *Client) error {
+		if n < 1 {
+			return fmt.Errorf("event workers: invalid number of workers %d", n)
+		}
+		c.eventWorkers = n
+		return nil
+	}
+}
+
+// Subscription is a registered event handler.
+type Subscription struct {
+	d       *dispatcher
+	filter  func(Event) bool
+	handler func(Event)
+
+	// Below here is protected by d.mtx.
+	queue     []Event
+	scheduled bool
+	cancelled bool
+}
+
+// Unsubscribe stops further events being delivered to the handler.
+// Events which have been queued but not yet handled are discarded.
+// It's safe to call Unsubscribe from within the handler and more than once.
+func (s *Subscription) Unsubscribe() {
+	s.d.remove(s)
+}
+
+// dispatcher delivers events to subscriptions using a pool of workers.
+//
+// Each subscription has its own queue which is processed in order by at
+// most one worker at a time, so a slow handler only delays its own events.
+type dispatcher struct {
+	workers int
+	once    sync.Once
+
+	// Below here is protected by mtx.
+	mtx    sync.Mutex
+	cond   *sync.Cond
+	subs   []*Subscription
+	runq   []*Subscription
+	closed bool
+}
+
+// newDispatcher returns a new dispatcher which will use up to workers
+// goroutines to run handlers.
+func newDispatcher(workers int) *dispatcher {
+	d := &dispatcher{workers: workers}
+	d.cond = sync.NewCond(&d.mtx)
+	return d
+}
+
+// add registers a new subscription.
+func (d *dispatcher) add(filter func(Event) bool, handler func(Event)) *Subscription {
+	d.once.Do(d.start)
+
+	s := &Subscription{d: d, filter: filter, handler: handler}
+
+	d.mtx.Lock()
+	defer d.mtx.Unlock()
+
+	d.subs = append(d.subs, s)
+
+	return s
+}
+
+// remove removes s from the dispatcher.
+func (d *dispatcher) remove(s *Subscription) {
+	d.mtx.Lock()
+	defer d.mtx.Unlock()
+
+	if s.cancelled {
+		return
+	}
+	s.cancelled = true
+	s.queue = nil
+
+	for i, v := range d.subs {
+		if v == s {
+			d.subs = append(d.subs[:i:i], d.subs[i+1:]...)
+			break
+		}
+	}
+}
+
+// active returns true if there are any subscriptions.
+func (d *dispatcher) active() bool {
+	d.mtx.Lock()
+	defer d.mtx.Unlock()
+
+	return len(d.subs) > 0
+}
+
+// dispatch queues e for all subscriptions whose filter matches.
+// It never blocks waiting for handlers.
+func (d *dispatcher) dispatch(e Event) {
+	d.mtx.Lock()
+	subs := d.subs
+	d.mtx.Unlock()
+
+	var matched []*Subscription
+	for _, s := range subs {
+		if s.filter == nil || s.filter(e) {
+			matched = append(matched, s)
+		}
+	}
+
+	if len(matched) == 0 {
+		return
+	}
+
+	d.mtx.Lock()
+	defer d.mtx.Unlock()
+
+	if d.closed {
+		return
+	}
+
+	for _, s := range matched {
+		if s.cancelled {
+			continue
+		}
+		s.queue = append(s.queue, e)
+		if !s.scheduled {
+			s.scheduled = true
+			d.runq = append(d.runq, s)
+			d.cond.Signal()
+		}
+	}
+}
+
+// start starts the workers.
+func (d *dispatcher) start() {
+	for i := 0; i < d.workers; i++ {
+		go d.worker()
+	}
+}
+
+// close stops the workers once all queued events have been handled.
+func (d *dispatcher) close() {
+	d.mtx.Lock()
+	defer d.mtx.Unlock()
+
+	d.closed = true
+	d.cond.Broadcast()
+}
+
+// worker runs handlers for scheduled subscriptions until the dispatcher
+// is closed and there's no more work to do.
+func (d *dispatcher) worker() {
+	d.mtx.Lock()
+	defer d.mtx.Unlock()
+
+	for {
+		for len(d.runq) == 0 {
+			if d.closed {
+				return
+			}
+			d.cond.Wait()
+		}
+
+		s := d.runq[0]
+		d.runq = d.runq[1:]
+		if s.cancelled || len(s.queue) == 0 {
+			s.scheduled = false
+			continue
+		}
+
+		e := s.queue[0]
+		s.queue = s.queue[1:]
+
+		d.mtx.Unlock()
+		s.handler(e)
+		d.mtx.Lock()
+
+		if !s.cancelled && len(s.queue) > 0 {
+			// Requeue at the back so other subscriptions get a fair share.
+			d.runq = append(d.runq, s)
+			d.cond.Signal()
+		} else {
+			s.scheduled = false
+		}
+	}
+}
+
+// Subscribe registers handler to be called for each notification event
+// for which filter returns true. If filter is nil handler is called for
+// all events.
+//
+// Events are decoded as per Notification.Event and handlers are run on
+// the pool of workers configured by EventWorkers. Each handler receives
+// its events in order, one at a time, independently of other handlers.
+// filter is called from the goroutine reading from the server so it
+// must be fast and must not block.
+//
+// Events are queued for each subscription without limit so handlers
+// should keep up with the rate of events they match.
+func (c *Client) Subscribe(filter func(Event) bool, handler func(Event)) *Subscription {
+	return c.dispatcher.add(filter, handler)
+}
+
+// OnNotification registers handler to be called for each event of
+// notification type typ. See Subscribe for details.
+func (c *Client) OnNotification(typ string, handler func(Event)) *Subscription {
+	return c.Subscribe(func(e Event) bool {
+		return e.EventType() == typ
+	}, handler)
+}
+
+// OnClientEnterView registers handler to be called for each ClientEnterView event.
+func (c *Client) OnClientEnterView(handler func(*ClientEnterView)) *Subscription {
+	return c.Subscribe(func(e Event) bool {
+		_, ok := e.(*ClientEnterView)
+		return ok
+	}, func(e Event) {
+		handler(e.(*ClientEnterView))
+	})
+}
+
+// OnClientLeftView registers handler to be called for each ClientLeftView event.
+func (c *Client) OnClientLeftView(handler func(*ClientLeftView)) *Subscription {
+	return c.Subscribe(func(e Event) bool {
+		_, ok := e.(*ClientLeftView)
+		return ok
+	}, func(e Event) {
+		handler(e.(*ClientLeftView))
+	})
+}
+
+// OnClientMoved registers handler to be called for each ClientMoved event.
+func (c *Client) OnClientMoved(handler func(*ClientMoved)) *Subscription {
+	return c.Subscribe(func(e Event) bool {
+		_, ok := e.(*ClientMoved)
+		return ok
+	}, func(e Event) {
+		handler(e.(*ClientMoved))
+	})
+}
+
+// OnTextMessage registers handler to be called for each TextMessage event.
+func (c *Client) OnTextMessage(handler func(*TextMessage)) *Subscription {
+	return c.Subscribe(func(e Event) bool {
+		_, ok := e.(*TextMessage)
+		return ok
+	}, func(e Event) {
+		handler(e.(*TextMessage))
+	})
+}
+
+// OnChannelCreated registers handler to be called for each ChannelCreated event.
+func (c *Client) OnChannelCreated(handler func(*ChannelCreated)) *Subscription {
+	return c.Subscribe(func(e Event) bool {
+		_, ok := e.(*ChannelCreated)
+		return ok
+	}, func(e Event) {
+		handler(e.(*ChannelCreated))
+	})
+}
+
+// OnChannelEdited registers handler to be called for each ChannelEdited event.
+func (c *Client) OnChannelEdited(handler func(*ChannelEdited)) *Subscription {
+	return c.Subscribe(func(e Event) bool {
+		_, ok := e.(*ChannelEdited)
+		return ok
+	}, func(e Event) {
+		handler(e.(*ChannelEdited))
+	})
+}
+
+// OnChannelDeleted registers handler to be called for each ChannelDeleted event.
+func (c *Client) OnChannelDeleted(handler func(*ChannelDeleted)) *Subscription {
+	return c.Subscribe(func(e Event) bool {
+		_, ok := e.(*ChannelDeleted)
+		return ok
+	}, func(e Event) {
+		handler(e.(*ChannelDeleted))
+	})
+}
+
+// OnChannelMoved registers handler to be called for each ChannelMoved event.
+func (c *Client) OnChannelMoved(handler func(*ChannelMoved)) *Subscription {
+	return c.Subscribe(func(e Event) bool {
+		_, ok := e.(*ChannelMoved)
+		return ok
+	}, func(e Event) {
+		handler(e.(*ChannelMoved))
+	})
+}
+
+// OnServerEdited registers handler to be called for each ServerEdited event.
+func (c *Client) OnServerEdited(handler func(*ServerEdited)) *Subscription {
+	return c.Subscribe(func(e Event) bool {
+		_, ok := e.(*ServerEdited)
+		return ok
+	}, func(e Event) {
+		handler(e.(*ServerEdited))
+	})
+}
+
+// OnTokenUsed registers handler to be called for each TokenUsed event.
+func (c *Client) OnTokenUsed(handler func(*TokenUsed)) *Subscription {
+	return c.Subscribe(func(e Event) bool {
+		_, ok := e.(*TokenUsed)
+		return ok
+	}, func(e Event) {
+		handler(e.(*TokenUsed))
+	})
+}
+
+// OnChannelDescriptionChanged registers handler to be called for each ChannelDescriptionChanged event.
+func (c *Client) OnChannelDescriptionChanged(handler func(*ChannelDescriptionChanged)) *Subscription {
+	return c.Subscribe(func(e Event) bool {
+		_, ok := e.(*ChannelDescriptionChanged)
+		return ok
+	}, func(e Event) {
+		handler(e.(*ChannelDescriptionChanged))
+	})
+}
+
+// OnChannelPasswordChanged registers handler to be called for each ChannelPasswordChanged event.
+func (c *Client) OnChannelPasswordChanged(handler func(*ChannelPasswordChanged)) *Subscription {
+	return c.Subscribe(func(e Event) bool {
+		_, ok := e.(*ChannelPasswordChanged)
+		return ok
+	}, func(e Event) {
+		handler(e.(*ChannelPasswordChanged))
+	})
+}
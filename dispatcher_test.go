@@ -0,0 +1,105 @@
+package ts3
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClientSubscribe(t *testing.T) {
+	s := newServer(t)
+	defer func() {
+		assert.NoError(t, s.Close())
+	}()
+
+	c, err := NewClient(s.Addr, Timeout(time.Second), EventWorkers(2))
+	require.NoError(t, err)
+
+	defer func() {
+		assert.NoError(t, c.Close())
+	}()
+
+	moved := make(chan *ClientMoved, 1)
+	c.OnClientMoved(func(e *ClientMoved) {
+		moved <- e
+	})
+
+	text := make(chan *TextMessage, 1)
+	c.OnTextMessage(func(e *TextMessage) {
+		text <- e
+	})
+
+	all := make(chan Event, 2)
+	c.Subscribe(nil, func(e Event) {
+		all <- e
+	})
+
+	unsubscribed := make(chan Event, 2)
+	sub := c.OnNotification(EventTextMessage, func(e Event) {
+		unsubscribed <- e
+	})
+	sub.Unsubscribe()
+	sub.Unsubscribe()
+
+	_, err = c.Exec(cmdNotify)
+	require.NoError(t, err)
+
+	select {
+	case e := <-moved:
+		assert.Equal(t, 12, e.ClientID)
+		assert.Equal(t, 9, e.ToChannelID)
+	case <-time.After(time.Second):
+		t.Fatal("no clientmoved event")
+	}
+
+	select {
+	case e := <-text:
+		assert.Equal(t, "hello", e.Message)
+		assert.Equal(t, "foobar", e.InvokerName)
+	case <-time.After(time.Second):
+		t.Fatal("no textmessage event")
+	}
+
+	for _, typ := range []string{EventClientMoved, EventTextMessage} {
+		select {
+		case e := <-all:
+			assert.Equal(t, typ, e.EventType())
+		case <-time.After(time.Second):
+			t.Fatalf("no %s event", typ)
+		}
+	}
+
+	assert.Empty(t, unsubscribed)
+}
+
+func TestDispatcherSlowHandler(t *testing.T) {
+	d := newDispatcher(2)
+	defer d.close()
+
+	block := make(chan struct{})
+	d.add(nil, func(e Event) {
+		<-block
+	})
+
+	got := make(chan Event, 10)
+	d.add(nil, func(e Event) {
+		got <- e
+	})
+
+	for i := 0; i < 10; i++ {
+		d.dispatch(&ChannelDeleted{ChannelID: i})
+	}
+
+	// All events must be delivered in order while the other handler is blocked.
+	for i := 0; i < 10; i++ {
+		select {
+		case e := <-got:
+			assert.Equal(t, i, e.(*ChannelDeleted).ChannelID)
+		case <-time.After(time.Second):
+			t.Fatalf("event %d not delivered", i)
+		}
+	}
+	close(block)
+}
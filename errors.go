@@ -21,6 +21,21 @@ var (
 	// ErrTimeout is returned by Exec and ExecCmd if no response is received
 	// within the specified timeout duration.
 	ErrTimeout = errors.New("timeout")
+
+	// ErrEmptyBanRule is returned by BanAdd if the rule doesn't set any
+	// of IP, Name, UniqueIdentifier or MyTSID.
+	ErrEmptyBanRule = errors.New("empty ban rule")
+
+	// ErrEmptySnapshot is returned when reading or deploying a snapshot
+	// which has no data.
+	ErrEmptySnapshot = errors.New("empty snapshot")
+
+	// ErrNilPerm is returned by permission methods if a permission is nil.
+	ErrNilPerm = errors.New("nil permission")
+
+	// ErrMultiLineSnapshot is returned by SnapshotDeploy if the snapshot
+	// was split over multiple lines by the server.
+	ErrMultiLineSnapshot = errors.New("multi-line snapshot")
 )
 
 // Error represents a error returned from the TeamSpeak 3 server.
@@ -77,3 +92,13 @@ func NewInvalidResponseError(reason string, lines []string) *InvalidResponseErro
 func (e *InvalidResponseError) Error() string {
 	return fmt.Sprintf("%v (%+v)", e.Reason, e.Data)
 }
+
+// errEmptyResult is the ID of the error returned by the server when a
+// list command has no results.
+const errEmptyResult = 1281
+
+// isEmptyResult returns true if err is the server reporting an empty result.
+func isEmptyResult(err error) bool {
+	var e *Error
+	return errors.As(err, &e) && e.ID == errEmptyResult
+}
@@ -0,0 +1,365 @@
+package ts3
+
+import (
+	"fmt"
+	"strconv"
+)
+
+// Notification types which can be decoded into typed events by Notification.Event.
+const (
+	EventClientEnterView           = "cliententerview"
+	EventClientLeftView            = "clientleftview"
+	EventClientMoved               = "clientmoved"
+	EventTextMessage               = "textmessage"
+	EventChannelCreated            = "channelcreated"
+	EventChannelEdited             = "channeledited"
+	EventChannelDeleted            = "channeldeleted"
+	EventChannelMoved              = "channelmoved"
+	EventServerEdited              = "serveredited"
+	EventTokenUsed                 = "tokenused"
+	EventChannelDescriptionChanged = "channeldescriptionchanged"
+	EventChannelPasswordChanged    = "channelpasswordchanged"
+)
+
+// Event is implemented by all typed notification events and Notification.
+type Event interface {
+	// EventType returns the notification type of the event.
+	EventType() string
+}
+
+// ReasonID is the reason for a client or channel related event.
+type ReasonID int
+
+// Reasons which can be returned in events.
+const (
+	ReasonSwitched       ReasonID = 0  // Client joined or switched channel.
+	ReasonMoved          ReasonID = 1  // Client was moved.
+	ReasonTimeout        ReasonID = 3  // Client connection timed out.
+	ReasonChannelKick    ReasonID = 4  // Client was kicked from the channel.
+	ReasonServerKick     ReasonID = 5  // Client was kicked from the server.
+	ReasonBan            ReasonID = 6  // Client was banned.
+	ReasonLeft           ReasonID = 8  // Client left the server.
+	ReasonEdited         ReasonID = 10 // Server or channel was edited.
+	ReasonServerShutdown ReasonID = 11 // Server was shut down.
+)
+
+// TextMessageTargetMode is the target of a text message.
+type TextMessageTargetMode int
+
+// Text message target modes.
+const (
+	TextMessageTargetClient  TextMessageTargetMode = 1
+	TextMessageTargetChannel TextMessageTargetMode = 2
+	TextMessageTargetServer  TextMessageTargetMode = 3
+)
+
+// Invoker identifies the client which triggered an event.
+type Invoker struct {
+	InvokerID   int    `ms:"invokerid"`
+	InvokerName string `ms:"invokername"`
+	InvokerUID  string `ms:"invokeruid"`
+}
+
+// ClientEnterView is the event sent when a client enters the view.
+type ClientEnterView struct {
+	FromChannelID                  int            `ms:"cfid"`
+	ToChannelID                    int            `ms:"ctid"`
+	Reason                         ReasonID       `ms:"reasonid"`
+	ClientID                       int            `ms:"clid"`
+	UniqueIdentifier               string         `ms:"client_unique_identifier"`
+	Nickname                       string         `ms:"client_nickname"`
+	InputMuted                     bool           `ms:"client_input_muted"`
+	OutputMuted                    bool           `ms:"client_output_muted"`
+	OutputOnlyMuted                bool           `ms:"client_outputonly_muted"`
+	InputHardware                  bool           `ms:"client_input_hardware"`
+	OutputHardware                 bool           `ms:"client_output_hardware"`
+	MetaData                       string         `ms:"client_meta_data"`
+	IsRecording                    bool           `ms:"client_is_recording"`
+	DatabaseID                     int            `ms:"client_database_id"`
+	ChannelGroupID                 int            `ms:"client_channel_group_id"`
+	ServerGroups                   []int          `ms:"client_servergroups"`
+	Away                           bool           `ms:"client_away"`
+	AwayMessage                    string         `ms:"client_away_message"`
+	Type                           int            `ms:"client_type"`
+	FlagAvatar                     string         `ms:"client_flag_avatar"`
+	TalkPower                      int            `ms:"client_talk_power"`
+	TalkRequest                    bool           `ms:"client_talk_request"`
+	TalkRequestMessage             string         `ms:"client_talk_request_msg"`
+	Description                    string         `ms:"client_description"`
+	IsTalker                       bool           `ms:"client_is_talker"`
+	IsPrioritySpeaker              bool           `ms:"client_is_priority_speaker"`
+	UnreadMessages                 int            `ms:"client_unread_messages"`
+	NicknamePhonetic               string         `ms:"client_nickname_phonetic"`
+	NeededServerQueryViewPower     int            `ms:"client_needed_serverquery_view_power"`
+	IconID                         int            `ms:"client_icon_id"`
+	IsChannelCommander             bool           `ms:"client_is_channel_commander"`
+	Country                        string         `ms:"client_country"`
+	ChannelGroupInheritedChannelID int            `ms:"client_channel_group_inherited_channel_id"`
+	Badges                         string         `ms:"client_badges"`
+	Invoker                        `ms:",squash"` // Only populated if Reason is ReasonMoved.
+}
+
+// EventType implements Event.
+func (ClientEnterView) EventType() string { return EventClientEnterView }
+
+// ClientLeftView is the event sent when a client leaves the view.
+type ClientLeftView struct {
+	FromChannelID int      `ms:"cfid"`
+	ToChannelID   int      `ms:"ctid"`
+	Reason        ReasonID `ms:"reasonid"`
+	ReasonMessage string   `ms:"reasonmsg"`
+	ClientID      int      `ms:"clid"`
+	BanTime       int      `ms:"bantime"` // Only populated if Reason is ReasonBan.
+	Invoker       `ms:",squash"`
+}
+
+// EventType implements Event.
+func (ClientLeftView) EventType() string { return EventClientLeftView }
+
+// ClientMoved is the event sent when a client switches or is moved to another channel.
+// When several clients are moved at once, ClientIDs lists all of them and
+// ClientID is the first.
+type ClientMoved struct {
+	ToChannelID int            `ms:"ctid"`
+	Reason      ReasonID       `ms:"reasonid"`
+	ClientID    int            `ms:"clid"`
+	ClientIDs   []int          `ms:"-"`
+	Invoker     `ms:",squash"` // Only populated if Reason is ReasonMoved.
+}
+
+// EventType implements Event.
+func (ClientMoved) EventType() string { return EventClientMoved }
+
+// TextMessage is the event sent when a text message is received.
+type TextMessage struct {
+	TargetMode TextMessageTargetMode `ms:"targetmode"`
+	Message    string                `ms:"msg"`
+	Target     int                   `ms:"target"` // Only populated if TargetMode is TextMessageTargetClient.
+	Invoker    `ms:",squash"`
+}
+
+// EventType implements Event.
+func (TextMessage) EventType() string { return EventTextMessage }
+
+// ChannelCreated is the event sent when a channel is created.
+type ChannelCreated struct {
+	ChannelID                     int    `ms:"cid"`
+	ParentID                      int    `ms:"cpid"`
+	Name                          string `ms:"channel_name"`
+	Topic                         string `ms:"channel_topic"`
+	Codec                         int    `ms:"channel_codec"`
+	CodecQuality                  int    `ms:"channel_codec_quality"`
+	MaxClients                    int    `ms:"channel_maxclients"`
+	MaxFamilyClients              int    `ms:"channel_maxfamilyclients"`
+	Order                         int    `ms:"channel_order"`
+	FlagPermanent                 bool   `ms:"channel_flag_permanent"`
+	FlagSemiPermanent             bool   `ms:"channel_flag_semi_permanent"`
+	FlagDefault                   bool   `ms:"channel_flag_default"`
+	FlagPassword                  bool   `ms:"channel_flag_password"`
+	CodecLatencyFactor            int    `ms:"channel_codec_latency_factor"`
+	CodecIsUnencrypted            bool   `ms:"channel_codec_is_unencrypted"`
+	DeleteDelay                   int    `ms:"channel_delete_delay"`
+	FlagMaxClientsUnlimited       bool   `ms:"channel_flag_maxclients_unlimited"`
+	FlagMaxFamilyClientsUnlimited bool   `ms:"channel_flag_maxfamilyclients_unlimited"`
+	FlagMaxFamilyClientsInherited bool   `ms:"channel_flag_maxfamilyclients_inherited"`
+	NeededTalkPower               int    `ms:"channel_needed_talk_power"`
+	NamePhonetic                  string `ms:"channel_name_phonetic"`
+	IconID                        int    `ms:"channel_icon_id"`
+	Invoker                       `ms:",squash"`
+}
+
+// EventType implements Event.
+func (ChannelCreated) EventType() string { return EventChannelCreated }
+
+// ChannelEdited is the event sent when a channel is edited.
+// Only the properties which were changed are populated.
+type ChannelEdited struct {
+	ChannelID                     int      `ms:"cid"`
+	Reason                        ReasonID `ms:"reasonid"`
+	Name                          *string  `ms:"channel_name"`
+	Topic                         *string  `ms:"channel_topic"`
+	Codec                         *int     `ms:"channel_codec"`
+	CodecQuality                  *int     `ms:"channel_codec_quality"`
+	MaxClients                    *int     `ms:"channel_maxclients"`
+	MaxFamilyClients              *int     `ms:"channel_maxfamilyclients"`
+	Order                         *int     `ms:"channel_order"`
+	FlagPermanent                 *bool    `ms:"channel_flag_permanent"`
+	FlagSemiPermanent             *bool    `ms:"channel_flag_semi_permanent"`
+	FlagDefault                   *bool    `ms:"channel_flag_default"`
+	FlagPassword                  *bool    `ms:"channel_flag_password"`
+	CodecLatencyFactor            *int     `ms:"channel_codec_latency_factor"`
+	CodecIsUnencrypted            *bool    `ms:"channel_codec_is_unencrypted"`
+	DeleteDelay                   *int     `ms:"channel_delete_delay"`
+	FlagMaxClientsUnlimited       *bool    `ms:"channel_flag_maxclients_unlimited"`
+	FlagMaxFamilyClientsUnlimited *bool    `ms:"channel_flag_maxfamilyclients_unlimited"`
+	FlagMaxFamilyClientsInherited *bool    `ms:"channel_flag_maxfamilyclients_inherited"`
+	NeededTalkPower               *int     `ms:"channel_needed_talk_power"`
+	NamePhonetic                  *string  `ms:"channel_name_phonetic"`
+	IconID                        *int     `ms:"channel_icon_id"`
+	Invoker                       `ms:",squash"`
+}
+
+// EventType implements Event.
+func (ChannelEdited) EventType() string { return EventChannelEdited }
+
+// ChannelDeleted is the event sent when a channel is deleted.
+type ChannelDeleted struct {
+	ChannelID int `ms:"cid"`
+	Invoker   `ms:",squash"`
+}
+
+// EventType implements Event.
+func (ChannelDeleted) EventType() string { return EventChannelDeleted }
+
+// ChannelMoved is the event sent when a channel is moved.
+type ChannelMoved struct {
+	ChannelID int      `ms:"cid"`
+	ParentID  int      `ms:"cpid"`
+	Order     int      `ms:"order"`
+	Reason    ReasonID `ms:"reasonid"`
+	Invoker   `ms:",squash"`
+}
+
+// EventType implements Event.
+func (ChannelMoved) EventType() string { return EventChannelMoved }
+
+// ServerEdited is the event sent when the virtual server is edited.
+// Only the properties which were changed are populated.
+type ServerEdited struct {
+	Reason                 ReasonID `ms:"reasonid"`
+	Name                   *string  `ms:"virtualserver_name"`
+	CodecEncryptionMode    *int     `ms:"virtualserver_codec_encryption_mode"`
+	DefaultServerGroup     *int     `ms:"virtualserver_default_server_group"`
+	DefaultChannelGroup    *int     `ms:"virtualserver_default_channel_group"`
+	HostBannerURL          *string  `ms:"virtualserver_hostbanner_url"`
+	HostBannerGFXURL       *string  `ms:"virtualserver_hostbanner_gfx_url"`
+	HostBannerGFXInterval  *int     `ms:"virtualserver_hostbanner_gfx_interval"`
+	PrioritySpeakerDimm    *float32 `ms:"virtualserver_priority_speaker_dimm_modificator"`
+	HostButtonToolTip      *string  `ms:"virtualserver_hostbutton_tooltip"`
+	HostButtonURL          *string  `ms:"virtualserver_hostbutton_url"`
+	HostButtonGFXURL       *string  `ms:"virtualserver_hostbutton_gfx_url"`
+	NamePhonetic           *string  `ms:"virtualserver_name_phonetic"`
+	IconID                 *int     `ms:"virtualserver_icon_id"`
+	HostBannerMode         *int     `ms:"virtualserver_hostbanner_mode"`
+	ChannelTempDeleteDelay *int     `ms:"virtualserver_channel_temp_delete_delay_default"`
+	Invoker                `ms:",squash"`
+}
+
+// EventType implements Event.
+func (ServerEdited) EventType() string { return EventServerEdited }
+
+// TokenUsed is the event sent when a privilege key is used.
+type TokenUsed struct {
+	ClientID         int    `ms:"clid"`
+	ClientDatabaseID int    `ms:"cldbid"`
+	ClientUID        string `ms:"cluid"`
+	Token            string `ms:"token"`
+	TokenCustomSet   string `ms:"tokencustomset"`
+	GroupID          int    `ms:"token1"`
+	ChannelID        int    `ms:"token2"`
+}
+
+// EventType implements Event.
+func (TokenUsed) EventType() string { return EventTokenUsed }
+
+// ChannelDescriptionChanged is the event sent when a channel description is changed.
+type ChannelDescriptionChanged struct {
+	ChannelID int `ms:"cid"`
+}
+
+// EventType implements Event.
+func (ChannelDescriptionChanged) EventType() string { return EventChannelDescriptionChanged }
+
+// ChannelPasswordChanged is the event sent when a channel password is changed.
+type ChannelPasswordChanged struct {
+	ChannelID int `ms:"cid"`
+}
+
+// EventType implements Event.
+func (ChannelPasswordChanged) EventType() string { return EventChannelPasswordChanged }
+
+// EventType implements Event.
+func (n Notification) EventType() string { return n.Type }
+
+// Event decodes the notification into its typed event.
+// If the type of n is unknown then n itself is returned.
+func (n Notification) Event() (Event, error) {
+	var e Event
+	switch n.Type {
+	case EventClientEnterView:
+		e = &ClientEnterView{}
+	case EventClientLeftView:
+		e = &ClientLeftView{}
+	case EventClientMoved:
+		e = &ClientMoved{}
+	case EventTextMessage:
+		e = &TextMessage{}
+	case EventChannelCreated:
+		e = &ChannelCreated{}
+	case EventChannelEdited:
+		e = &ChannelEdited{}
+	case EventChannelDeleted:
+		e = &ChannelDeleted{}
+	case EventChannelMoved:
+		e = &ChannelMoved{}
+	case EventServerEdited:
+		e = &ServerEdited{}
+	case EventTokenUsed:
+		e = &TokenUsed{}
+	case EventChannelDescriptionChanged:
+		e = &ChannelDescriptionChanged{}
+	case EventChannelPasswordChanged:
+		e = &ChannelPasswordChanged{}
+	default:
+		return n, nil
+	}
+
+	input := make(map[string]interface{}, len(n.Data))
+	for k, v := range n.Data {
+		val, err := decodeValue(k, v)
+		if err != nil {
+			return nil, fmt.Errorf("event %s: %w", n.Type, err)
+		}
+		input[k] = val
+	}
+
+	if err := decodeMap(input, e); err != nil {
+		return nil, fmt.Errorf("event %s: %w", n.Type, err)
+	}
+
+	if m, ok := e.(*ClientMoved); ok {
+		ids, err := n.entryInts("clid")
+		if err != nil {
+			return nil, fmt.Errorf("event %s: %w", n.Type, err)
+		}
+		if len(ids) > 0 {
+			m.ClientID = ids[0]
+		}
+		m.ClientIDs = ids
+	}
+
+	return e, nil
+}
+
+// entryInts returns the integer values of key from each entry of n.
+func (n Notification) entryInts(key string) ([]int, error) {
+	entries := n.entries
+	if len(entries) == 0 {
+		entries = []map[string]string{n.Data}
+	}
+
+	var vals []int
+	for _, entry := range entries {
+		v, ok := entry[key]
+		if !ok {
+			continue
+		}
+		i, err := strconv.Atoi(v)
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s %q: %w", key, v, err)
+		}
+		vals = append(vals, i)
+	}
+
+	return vals, nil
+}
@@ -0,0 +1,158 @@
+package ts3
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNotificationEvent(t *testing.T) {
+	name := "Lobby"
+	order := 3
+	tests := map[string]struct {
+		line     string
+		expected Event
+	}{
+		"cliententerview": {
+			`notifycliententerview cfid=0 ctid=5 reasonid=0 clid=12 client_unique_identifier=abc= client_nickname=foo\sbar client_input_muted=1 client_database_id=7 client_servergroups=6,8 client_away=0 client_away_message client_type=0 client_talk_power=75`,
+			&ClientEnterView{
+				ToChannelID:      5,
+				ClientID:         12,
+				UniqueIdentifier: "abc=",
+				Nickname:         "foo bar",
+				InputMuted:       true,
+				DatabaseID:       7,
+				ServerGroups:     []int{6, 8},
+				TalkPower:        75,
+			},
+		},
+		"cliententerview-single-group": {
+			`notifycliententerview cfid=0 ctid=5 reasonid=0 clid=12 client_servergroups=6`,
+			&ClientEnterView{
+				ToChannelID:  5,
+				ClientID:     12,
+				ServerGroups: []int{6},
+			},
+		},
+		"clientleftview": {
+			`notifyclientleftview cfid=5 ctid=0 reasonid=5 reasonmsg=bye invokerid=1 invokername=admin invokeruid=xyz= clid=12`,
+			&ClientLeftView{
+				FromChannelID: 5,
+				Reason:        ReasonServerKick,
+				ReasonMessage: "bye",
+				ClientID:      12,
+				Invoker:       Invoker{InvokerID: 1, InvokerName: "admin", InvokerUID: "xyz="},
+			},
+		},
+		"clientmoved": {
+			`notifyclientmoved ctid=9 reasonid=1 invokerid=1 invokername=admin invokeruid=xyz= clid=12`,
+			&ClientMoved{
+				ToChannelID: 9,
+				Reason:      ReasonMoved,
+				ClientID:    12,
+				ClientIDs:   []int{12},
+				Invoker:     Invoker{InvokerID: 1, InvokerName: "admin", InvokerUID: "xyz="},
+			},
+		},
+		"clientmoved-multiple": {
+			`notifyclientmoved ctid=9 reasonid=1 invokerid=1 invokername=admin invokeruid=xyz= clid=12|clid=13|clid=14`,
+			&ClientMoved{
+				ToChannelID: 9,
+				Reason:      ReasonMoved,
+				ClientID:    12,
+				ClientIDs:   []int{12, 13, 14},
+				Invoker:     Invoker{InvokerID: 1, InvokerName: "admin", InvokerUID: "xyz="},
+			},
+		},
+		"textmessage": {
+			`notifytextmessage targetmode=1 msg=lorem\sipsum target=3 invokerid=42 invokername=foobar invokeruid=something=`,
+			&TextMessage{
+				TargetMode: TextMessageTargetClient,
+				Message:    "lorem ipsum",
+				Target:     3,
+				Invoker:    Invoker{InvokerID: 42, InvokerName: "foobar", InvokerUID: "something="},
+			},
+		},
+		"channelcreated": {
+			`notifychannelcreated cid=20 cpid=1 channel_name=New channel_order=4 channel_flag_permanent=1 invokerid=1 invokername=admin invokeruid=xyz=`,
+			&ChannelCreated{
+				ChannelID:     20,
+				ParentID:      1,
+				Name:          "New",
+				Order:         4,
+				FlagPermanent: true,
+				Invoker:       Invoker{InvokerID: 1, InvokerName: "admin", InvokerUID: "xyz="},
+			},
+		},
+		"channeledited": {
+			`notifychanneledited cid=20 reasonid=10 invokerid=1 invokername=admin invokeruid=xyz= channel_name=Lobby channel_order=3`,
+			&ChannelEdited{
+				ChannelID: 20,
+				Reason:    ReasonEdited,
+				Name:      &name,
+				Order:     &order,
+				Invoker:   Invoker{InvokerID: 1, InvokerName: "admin", InvokerUID: "xyz="},
+			},
+		},
+		"channeldeleted": {
+			`notifychanneldeleted invokerid=1 invokername=admin invokeruid=xyz= cid=20`,
+			&ChannelDeleted{
+				ChannelID: 20,
+				Invoker:   Invoker{InvokerID: 1, InvokerName: "admin", InvokerUID: "xyz="},
+			},
+		},
+		"channelmoved": {
+			`notifychannelmoved cid=20 cpid=2 order=5 reasonid=1 invokerid=1 invokername=admin invokeruid=xyz=`,
+			&ChannelMoved{
+				ChannelID: 20,
+				ParentID:  2,
+				Order:     5,
+				Reason:    ReasonMoved,
+				Invoker:   Invoker{InvokerID: 1, InvokerName: "admin", InvokerUID: "xyz="},
+			},
+		},
+		"serveredited": {
+			`notifyserveredited reasonid=10 invokerid=1 invokername=admin invokeruid=xyz= virtualserver_name=Lobby`,
+			&ServerEdited{
+				Reason:  ReasonEdited,
+				Name:    &name,
+				Invoker: Invoker{InvokerID: 1, InvokerName: "admin", InvokerUID: "xyz="},
+			},
+		},
+		"tokenused": {
+			`notifytokenused clid=12 cldbid=7 cluid=abc= token=tok tokencustomset token1=6 token2=0`,
+			&TokenUsed{
+				ClientID:         12,
+				ClientDatabaseID: 7,
+				ClientUID:        "abc=",
+				Token:            "tok",
+				GroupID:          6,
+			},
+		},
+		"channeldescriptionchanged": {
+			`notifychanneldescriptionchanged cid=20`,
+			&ChannelDescriptionChanged{ChannelID: 20},
+		},
+		"channelpasswordchanged": {
+			`notifychannelpasswordchanged cid=20`,
+			&ChannelPasswordChanged{ChannelID: 20},
+		},
+		"unknown": {
+			`notifyunknown foo=bar`,
+			Notification{Type: "unknown", Data: map[string]string{"foo": "bar"}},
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			n, err := decodeNotification(tc.line)
+			require.NoError(t, err)
+
+			e, err := n.Event()
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, e)
+			assert.Equal(t, n.Type, e.EventType())
+		})
+	}
+}
@@ -0,0 +1,186 @@
+package ts3
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net"
+	"strconv"
+	"strings"
+)
+
+// FileTransferOptions configures FileUpload and FileDownload.
+type FileTransferOptions struct {
+	// Password is the password of the channel.
+	Password string
+
+	// Overwrite replaces an existing file when uploading.
+	Overwrite bool
+
+	// Resume continues a previously interrupted upload from the offset
+	// reported by the server.
+	Resume bool
+
+	// Offset is the offset from which to download e.g. the number of
+	// bytes already received by a previously interrupted download.
+	Offset int64
+
+	// Progress, if set, is called as data is transferred with the number
+	// of bytes of the file transferred so far, including any skipped by
+	// Resume or Offset, and the size of the file.
+	Progress func(done, total int64)
+}
+
+// progressWriter is an io.Writer which reports progress.
+type progressWriter struct {
+	w     io.Writer
+	done  int64
+	total int64
+	fn    func(done, total int64)
+}
+
+// Write implements io.Writer.
+func (pw *progressWriter) Write(p []byte) (int, error) {
+	n, err := pw.w.Write(p)
+	pw.done += int64(n)
+	if pw.fn != nil && n > 0 {
+		pw.fn(pw.done, pw.total)
+	}
+	return n, err
+}
+
+// FileUpload uploads size bytes read from r to the file name, an absolute
+// path e.g. "/dir/file.txt", in the file repository of channel cid.
+// r must be positioned at the start of the file; if the upload is resumed
+// the data already on the server is skipped by seeking, if r is an
+// io.Seeker, or by reading.
+// opts may be nil.
+func (s *ServerMethods) FileUpload(cid int, name string, r io.Reader, size int64, opts *FileTransferOptions) error {
+	return s.FileUploadContext(context.Background(), cid, name, r, size, opts)
+}
+
+// FileUploadContext uploads size bytes read from r to the file name in the
+// file repository of channel cid.
+func (s *ServerMethods) FileUploadContext(ctx context.Context, cid int, name string, r io.Reader, size int64, opts *FileTransferOptions) error {
+	if opts == nil {
+		opts = &FileTransferOptions{}
+	}
+
+	ft, err := s.FileInitUploadContext(ctx, cid, opts.Password, name, size, opts.Overwrite, opts.Resume)
+	if err != nil {
+		return err
+	}
+
+	if ft.SeekPos > 0 {
+		if sr, ok := r.(io.Seeker); ok {
+			_, err = sr.Seek(ft.SeekPos, io.SeekStart)
+		} else {
+			_, err = io.CopyN(ioutil.Discard, r, ft.SeekPos)
+		}
+		if err != nil {
+			return fmt.Errorf("file upload: skip: %w", err)
+		}
+	}
+
+	return s.transferFile(ctx, ft, func(conn net.Conn) error {
+		w := &progressWriter{w: conn, done: ft.SeekPos, total: size, fn: opts.Progress}
+		if _, err := io.CopyN(w, r, size-ft.SeekPos); err != nil {
+			return fmt.Errorf("file upload: %w", err)
+		}
+		return nil
+	})
+}
+
+// FileDownload downloads the file name, an absolute path e.g. "/dir/file.txt",
+// from the file repository of channel cid writing its content to w.
+// It returns the number of bytes written to w.
+// opts may be nil.
+func (s *ServerMethods) FileDownload(cid int, name string, w io.Writer, opts *FileTransferOptions) (int64, error) {
+	return s.FileDownloadContext(context.Background(), cid, name, w, opts)
+}
+
+// FileDownloadContext downloads the file name from the file repository of
+// channel cid writing its content to w.
+func (s *ServerMethods) FileDownloadContext(ctx context.Context, cid int, name string, w io.Writer, opts *FileTransferOptions) (int64, error) {
+	if opts == nil {
+		opts = &FileTransferOptions{}
+	}
+
+	ft, err := s.FileInitDownloadContext(ctx, cid, opts.Password, name, opts.Offset)
+	if err != nil {
+		return 0, err
+	}
+
+	var n int64
+	err = s.transferFile(ctx, ft, func(conn net.Conn) error {
+		pw := &progressWriter{w: w, done: opts.Offset, total: ft.Size, fn: opts.Progress}
+		if n, err = io.CopyN(pw, conn, ft.Size-opts.Offset); err != nil {
+			return fmt.Errorf("file download: %w", err)
+		}
+		return nil
+	})
+
+	return n, err
+}
+
+// transferFile connects to the file transfer port of ft, sends its key
+// and then calls fn to transfer the data.
+// The connection is closed if ctx is done before fn returns.
+func (s *ServerMethods) transferFile(ctx context.Context, ft *FileTransfer, fn func(net.Conn) error) error {
+	addr, err := s.fileTransferAddr(ft)
+	if err != nil {
+		return err
+	}
+
+	d := &net.Dialer{Timeout: s.timeout}
+	conn, err := d.DialContext(ctx, "tcp", addr)
+	if err != nil {
+		return fmt.Errorf("file transfer: dial: %w", err)
+	}
+	defer conn.Close() //nolint: errcheck
+
+	done := make(chan struct{})
+	defer close(done)
+	go func() {
+		select {
+		case <-ctx.Done():
+			conn.Close() //nolint: errcheck
+		case <-done:
+		}
+	}()
+
+	if _, err = io.WriteString(conn, ft.Key); err == nil {
+		err = fn(conn)
+	}
+
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+
+	return err
+}
+
+// fileTransferAddr returns the address of the file transfer port of ft.
+// The first specific address reported by the server is used, otherwise
+// the host the client is connected to.
+func (s *ServerMethods) fileTransferAddr(ft *FileTransfer) (string, error) {
+	addr, err := verifyAddr(s.addr, DefaultPort)
+	if err != nil {
+		return "", err
+	}
+
+	host, _, err := net.SplitHostPort(addr)
+	if err != nil {
+		return "", fmt.Errorf("file transfer: %w", err)
+	}
+
+	for _, v := range strings.Split(ft.IP, ",") {
+		if ip := net.ParseIP(strings.TrimSpace(v)); ip != nil && !ip.IsUnspecified() {
+			host = ip.String()
+			break
+		}
+	}
+
+	return net.JoinHostPort(host, strconv.Itoa(ft.Port)), nil
+}
@@ -0,0 +1,284 @@
+package ts3
+
+import (
+	"context"
+	"sync/atomic"
+	"time"
+)
+
+// FileType is the type of an entry in a channel's file repository.
+type FileType int
+
+const (
+	// FileTypeDirectory is a directory.
+	FileTypeDirectory FileType = iota
+	// FileTypeFile is a regular file.
+	FileTypeFile
+)
+
+// File represents an entry in a channel's file repository.
+type File struct {
+	ChannelID int       `ms:"cid"`
+	Path      string    `ms:"path"` // Only populated by FileList.
+	Name      string    `ms:"name"`
+	Size      int64     `ms:"size"`
+	Modified  time.Time `ms:"datetime"`
+	Type      FileType  `ms:"type"` // Only populated by FileList.
+}
+
+// FileTransfer represents a file transfer initialised by FileInitUpload
+// or FileInitDownload.
+type FileTransfer struct {
+	ClientTransferID int    `ms:"clientftfid"`
+	ServerTransferID int    `ms:"serverftfid"`
+	Key              string `ms:"ftkey"`
+	Port             int    `ms:"port"`
+	IP               string `ms:"ip"`      // Comma separated list of addresses, may be empty.
+	SeekPos          int64  `ms:"seekpos"` // Only populated for uploads.
+	Size             int64  `ms:"size"`    // Only populated for downloads.
+	Status           int    `ms:"status"`
+	Message          string `ms:"msg"`
+}
+
+// FileTransferStatus represents an active file transfer.
+type FileTransferStatus struct {
+	ClientID         int     `ms:"clid"`
+	Path             string  `ms:"path"`
+	Name             string  `ms:"name"`
+	Size             int64   `ms:"size"`
+	SizeDone         int64   `ms:"sizedone"`
+	ClientTransferID int     `ms:"clientftfid"`
+	ServerTransferID int     `ms:"serverftfid"`
+	Sender           int     `ms:"sender"`
+	Status           int     `ms:"status"`
+	CurrentSpeed     float64 `ms:"current_speed"` // In bytes per second.
+	AverageSpeed     float64 `ms:"average_speed"` // In bytes per second.
+	Runtime          int     `ms:"runtime"`       // In milliseconds.
+}
+
+// nextTransferID returns a new client file transfer ID.
+func (s *ServerMethods) nextTransferID() int {
+	return int(atomic.AddUint32(&s.transferID, 1))
+}
+
+// initTransfer executes cmd, a file transfer initialisation, and returns
+// the transfer. A failure reported in the response is returned as an *Error.
+func (s *ServerMethods) initTransfer(ctx context.Context, cmd *Cmd) (*FileTransfer, error) {
+	ft := &FileTransfer{}
+	if _, err := s.ExecCmdContext(ctx, cmd.WithResponse(ft)); err != nil {
+		return nil, err
+	}
+
+	if ft.Status != 0 {
+		return nil, &Error{ID: ft.Status, Msg: ft.Message}
+	}
+
+	return ft, nil
+}
+
+// FileInitUpload initialises the upload of size bytes to the file name, an
+// absolute path e.g. "/dir/file.txt", in the file repository of channel cid
+// protected by password cpw.
+// If resume is true and a previous upload was interrupted the returned
+// SeekPos is the offset from which the data should be sent.
+// The data is sent by connecting to the returned Port, see FileUpload.
+func (s *ServerMethods) FileInitUpload(cid int, cpw, name string, size int64, overwrite, resume bool) (*FileTransfer, error) {
+	return s.FileInitUploadContext(context.Background(), cid, cpw, name, size, overwrite, resume)
+}
+
+// FileInitUploadContext initialises the upload of size bytes to the file name.
+func (s *ServerMethods) FileInitUploadContext(ctx context.Context, cid int, cpw, name string, size int64, overwrite, resume bool) (*FileTransfer, error) {
+	return s.initTransfer(ctx, NewCmd("ftinitupload").WithArgs(
+		NewArg("clientftfid", s.nextTransferID()),
+		NewArg("name", name),
+		NewArg("cid", cid),
+		NewArg("cpw", cpw),
+		NewArg("size", size),
+		NewArg("overwrite", overwrite),
+		NewArg("resume", resume),
+	))
+}
+
+// FileInitDownload initialises the download of the file name, an absolute
+// path e.g. "/dir/file.txt", from the file repository of channel cid protected
+// by password cpw, starting at offset seekPos.
+// The data is received by connecting to the returned Port, see FileDownload.
+func (s *ServerMethods) FileInitDownload(cid int, cpw, name string, seekPos int64) (*FileTransfer, error) {
+	return s.FileInitDownloadContext(context.Background(), cid, cpw, name, seekPos)
+}
+
+// FileInitDownloadContext initialises the download of the file name.
+func (s *ServerMethods) FileInitDownloadContext(ctx context.Context, cid int, cpw, name string, seekPos int64) (*FileTransfer, error) {
+	return s.initTransfer(ctx, NewCmd("ftinitdownload").WithArgs(
+		NewArg("clientftfid", s.nextTransferID()),
+		NewArg("name", name),
+		NewArg("cid", cid),
+		NewArg("cpw", cpw),
+		NewArg("seekpos", seekPos),
+	))
+}
+
+// FileList returns the entries of the directory path in the file
+// repository of channel cid protected by password cpw.
+func (s *ServerMethods) FileList(cid int, cpw, path string) ([]*File, error) {
+	return s.FileListContext(context.Background(), cid, cpw, path)
+}
+
+// FileListContext returns the entries of the directory path in the file
+// repository of channel cid.
+func (s *ServerMethods) FileListContext(ctx context.Context, cid int, cpw, path string) ([]*File, error) {
+	var files []*File
+	if _, err := s.ExecCmdContext(ctx, NewCmd("ftgetfilelist").WithArgs(
+		NewArg("cid", cid),
+		NewArg("cpw", cpw),
+		NewArg("path", path),
+	).WithResponse(&files)); err != nil {
+		if isEmptyResult(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	// Only the first entry includes the channel and path.
+	for _, f := range files {
+		f.ChannelID = cid
+		f.Path = path
+	}
+
+	return files, nil
+}
+
+// FileInfo returns information about the files names in the file
+// repository of channel cid protected by password cpw.
+func (s *ServerMethods) FileInfo(cid int, cpw string, names ...string) ([]*File, error) {
+	return s.FileInfoContext(context.Background(), cid, cpw, names...)
+}
+
+// FileInfoContext returns information about the files names in the file
+// repository of channel cid.
+func (s *ServerMethods) FileInfoContext(ctx context.Context, cid int, cpw string, names ...string) ([]*File, error) {
+	files := make([]CmdArg, len(names))
+	for i, n := range names {
+		files[i] = NewArgSet(NewArg("cid", cid), NewArg("cpw", cpw), NewArg("name", n))
+	}
+
+	var info []*File
+	if _, err := s.ExecCmdContext(ctx, NewCmd("ftgetfileinfo").WithArgs(
+		NewArgGroup(files...),
+	).WithResponse(&info)); err != nil {
+		return nil, err
+	}
+
+	return info, nil
+}
+
+// FileTransferList returns the active file transfers on the selected server.
+func (s *ServerMethods) FileTransferList() ([]*FileTransferStatus, error) {
+	return s.FileTransferListContext(context.Background())
+}
+
+// FileTransferListContext returns the active file transfers on the selected server.
+func (s *ServerMethods) FileTransferListContext(ctx context.Context) ([]*FileTransferStatus, error) {
+	var transfers []*FileTransferStatus
+	if _, err := s.ExecCmdContext(ctx, NewCmd("ftlist").WithResponse(&transfers)); err != nil {
+		if isEmptyResult(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return transfers, nil
+}
+
+// FileTransferStop stops the file transfer with the server transfer id.
+// If remove is true the partially transferred file is deleted.
+func (s *ServerMethods) FileTransferStop(id int, remove bool) error {
+	return s.FileTransferStopContext(context.Background(), id, remove)
+}
+
+// FileTransferStopContext stops the file transfer with the server transfer id.
+func (s *ServerMethods) FileTransferStopContext(ctx context.Context, id int, remove bool) error {
+	_, err := s.ExecCmdContext(ctx, NewCmd("ftstop").WithArgs(
+		NewArg("serverftfid", id),
+		NewArg("delete", remove),
+	))
+	return err
+}
+
+// FileDelete deletes the files or directories names from the file
+// repository of channel cid protected by password cpw.
+func (s *ServerMethods) FileDelete(cid int, cpw string, names ...string) error {
+	return s.FileDeleteContext(context.Background(), cid, cpw, names...)
+}
+
+// FileDeleteContext deletes the files or directories names from the file
+// repository of channel cid.
+func (s *ServerMethods) FileDeleteContext(ctx context.Context, cid int, cpw string, names ...string) error {
+	files := make([]CmdArg, len(names))
+	for i, n := range names {
+		files[i] = NewArg("name", n)
+	}
+
+	_, err := s.ExecCmdContext(ctx, NewCmd("ftdeletefile").WithArgs(
+		NewArg("cid", cid),
+		NewArg("cpw", cpw),
+		NewArgGroup(files...),
+	))
+	return err
+}
+
+// FileCreateDir creates the directory dir in the file repository of
+// channel cid protected by password cpw.
+func (s *ServerMethods) FileCreateDir(cid int, cpw, dir string) error {
+	return s.FileCreateDirContext(context.Background(), cid, cpw, dir)
+}
+
+// FileCreateDirContext creates the directory dir in the file repository of
+// channel cid.
+func (s *ServerMethods) FileCreateDirContext(ctx context.Context, cid int, cpw, dir string) error {
+	_, err := s.ExecCmdContext(ctx, NewCmd("ftcreatedir").WithArgs(
+		NewArg("cid", cid),
+		NewArg("cpw", cpw),
+		NewArg("dirname", dir),
+	))
+	return err
+}
+
+// FileRename renames the file oldName to newName in the file repository
+// of channel cid protected by password cpw.
+func (s *ServerMethods) FileRename(cid int, cpw, oldName, newName string) error {
+	return s.FileRenameContext(context.Background(), cid, cpw, oldName, newName)
+}
+
+// FileRenameContext renames the file oldName to newName in the file repository
+// of channel cid.
+func (s *ServerMethods) FileRenameContext(ctx context.Context, cid int, cpw, oldName, newName string) error {
+	_, err := s.ExecCmdContext(ctx, NewCmd("ftrenamefile").WithArgs(
+		NewArg("cid", cid),
+		NewArg("cpw", cpw),
+		NewArg("oldname", oldName),
+		NewArg("newname", newName),
+	))
+	return err
+}
+
+// FileMove moves the file oldName in the file repository of channel cid
+// protected by password cpw to newName in the file repository of channel
+// targetCID protected by password targetCPW.
+func (s *ServerMethods) FileMove(cid int, cpw, oldName string, targetCID int, targetCPW, newName string) error {
+	return s.FileMoveContext(context.Background(), cid, cpw, oldName, targetCID, targetCPW, newName)
+}
+
+// FileMoveContext moves the file oldName in the file repository of channel cid
+// to newName in the file repository of channel targetCID.
+func (s *ServerMethods) FileMoveContext(ctx context.Context, cid int, cpw, oldName string, targetCID int, targetCPW, newName string) error {
+	_, err := s.ExecCmdContext(ctx, NewCmd("ftrenamefile").WithArgs(
+		NewArg("cid", cid),
+		NewArg("cpw", cpw),
+		NewArg("tcid", targetCID),
+		NewArg("tcpw", targetCPW),
+		NewArg("oldname", oldName),
+		NewArg("newname", newName),
+	))
+	return err
+}
@@ -0,0 +1,154 @@
+package ts3
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCmdsFileTransfer(t *testing.T) {
+	s := newServer(t)
+	defer func() {
+		assert.NoError(t, s.Close())
+	}()
+
+	c, err := NewClient(s.Addr, Timeout(time.Second*2))
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	defer func() {
+		assert.NoError(t, c.Close())
+	}()
+
+	testCmdsFileTransfer(t, c, s)
+}
+
+func TestCmdsFileTransferSSH(t *testing.T) {
+	s := newServer(t, useSSH())
+	defer func() {
+		assert.NoError(t, s.Close())
+	}()
+
+	c, err := NewClient(s.Addr, Timeout(time.Second*2), SSH(sshClientTestConfig))
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	defer func() {
+		assert.NoError(t, c.Close())
+	}()
+
+	testCmdsFileTransfer(t, c, s)
+}
+
+func testCmdsFileTransfer(t *testing.T, c *Client, s *server) {
+	t.Helper()
+	lastCmd := func() string {
+		received := s.Received()
+		return received[len(received)-1]
+	}
+
+	ftgetfilelist := func(t *testing.T) {
+		t.Helper()
+		files, err := c.Server.FileList(2, "", "/")
+		if !assert.NoError(t, err) {
+			return
+		}
+
+		expected := []*File{
+			{ChannelID: 2, Path: "/", Name: "Stuff", Modified: time.Unix(1259415210, 0), Type: FileTypeDirectory},
+			{ChannelID: 2, Path: "/", Name: "Pic1.PNG", Size: 563783, Modified: time.Unix(1259425462, 0), Type: FileTypeFile},
+		}
+		assert.Equal(t, expected, files)
+		assert.Equal(t, `ftgetfilelist cid=2 cpw= path=\/`, lastCmd())
+
+		files, err = c.Server.FileList(2, "", "/empty")
+		assert.NoError(t, err)
+		assert.Empty(t, files)
+	}
+
+	ftgetfileinfo := func(t *testing.T) {
+		t.Helper()
+		files, err := c.Server.FileInfo(2, "secret", "/Pic1.PNG", "/Pic2.PNG")
+		if !assert.NoError(t, err) {
+			return
+		}
+
+		expected := []*File{
+			{ChannelID: 2, Name: "/Pic1.PNG", Size: 563783, Modified: time.Unix(1259425462, 0)},
+			{ChannelID: 2, Name: "/Pic2.PNG", Size: 1234, Modified: time.Unix(1259415210, 0)},
+		}
+		assert.Equal(t, expected, files)
+		assert.Equal(t, `ftgetfileinfo cid=2 cpw=secret name=\/Pic1.PNG|cid=2 cpw=secret name=\/Pic2.PNG`, lastCmd())
+	}
+
+	ftlist := func(t *testing.T) {
+		t.Helper()
+		transfers, err := c.Server.FileTransferList()
+		if !assert.NoError(t, err) {
+			return
+		}
+
+		expected := []*FileTransferStatus{
+			{
+				ClientID:         2,
+				Path:             "files/virtualserver_1/channel_5",
+				Name:             "image.iso",
+				Size:             673460224,
+				SizeDone:         450756,
+				ClientTransferID: 2,
+				ServerTransferID: 6,
+				Status:           1,
+				CurrentSpeed:     37876.8,
+				Runtime:          150,
+			},
+		}
+		assert.Equal(t, expected, transfers)
+	}
+
+	ftstop := func(t *testing.T) {
+		t.Helper()
+		assert.NoError(t, c.Server.FileTransferStop(6, true))
+		assert.Equal(t, "ftstop serverftfid=6 delete=1", lastCmd())
+	}
+
+	ftdeletefile := func(t *testing.T) {
+		t.Helper()
+		assert.NoError(t, c.Server.FileDelete(2, "", "/a.txt", "/b"))
+		assert.Equal(t, `ftdeletefile cid=2 cpw= name=\/a.txt|name=\/b`, lastCmd())
+	}
+
+	ftcreatedir := func(t *testing.T) {
+		t.Helper()
+		assert.NoError(t, c.Server.FileCreateDir(2, "", "/new dir"))
+		assert.Equal(t, `ftcreatedir cid=2 cpw= dirname=\/new\sdir`, lastCmd())
+	}
+
+	ftrenamefile := func(t *testing.T) {
+		t.Helper()
+		assert.NoError(t, c.Server.FileRename(2, "", "/a.txt", "/b.txt"))
+		assert.Equal(t, `ftrenamefile cid=2 cpw= oldname=\/a.txt newname=\/b.txt`, lastCmd())
+
+		assert.NoError(t, c.Server.FileMove(2, "", "/a.txt", 3, "pw", "/b.txt"))
+		assert.Equal(t, `ftrenamefile cid=2 cpw= tcid=3 tcpw=pw oldname=\/a.txt newname=\/b.txt`, lastCmd())
+	}
+
+	tests := []struct {
+		name string
+		f    func(t *testing.T)
+	}{
+		{"ftgetfilelist", ftgetfilelist},
+		{"ftgetfileinfo", ftgetfileinfo},
+		{"ftlist", ftlist},
+		{"ftstop", ftstop},
+		{"ftdeletefile", ftdeletefile},
+		{"ftcreatedir", ftcreatedir},
+		{"ftrenamefile", ftrenamefile},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, tc.f)
+	}
+}
@@ -0,0 +1,212 @@
+package ts3
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/multiplay/go-ts3/ts3test"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newFileTransferTest returns a client connected to a new server with a
+// file transfer server and a function which closes them all.
+func newFileTransferTest(t *testing.T) (*Client, *server, *ts3test.FileTransferServer, func()) {
+	t.Helper()
+	s := newServer(t)
+	ft, err := ts3test.NewFileTransferServer()
+	require.NoError(t, err)
+	c, err := NewClient(s.Addr, Timeout(time.Second*2))
+	require.NoError(t, err)
+
+	return c, s, ft, func() {
+		assert.NoError(t, c.Close())
+		assert.NoError(t, ft.Close())
+		assert.NoError(t, s.Close())
+	}
+}
+
+// progress records the values reported by a Progress callback.
+type progress struct {
+	done, total int64
+	calls       int
+}
+
+func (p *progress) update(done, total int64) {
+	p.done, p.total = done, total
+	p.calls++
+}
+
+func TestFileUpload(t *testing.T) {
+	c, s, ft, done := newFileTransferTest(t)
+	defer done()
+
+	const key = "upload0000000000"
+	ft.AddUpload(key)
+	s.setResponse("ftinitupload", fmt.Sprintf("clientftfid=1 serverftfid=6 ftkey=%s port=%d seekpos=0", key, ft.Port))
+
+	var p progress
+	data := "hello world"
+	err := c.Server.FileUpload(5, "/file.txt", strings.NewReader(data), int64(len(data)), &FileTransferOptions{
+		Overwrite: true,
+		Progress:  p.update,
+	})
+	require.NoError(t, err)
+
+	received := s.Received()
+	assert.Regexp(t, `^ftinitupload clientftfid=\d+ name=\\/file.txt cid=5 cpw= size=11 overwrite=1 resume=0$`, received[len(received)-1])
+	assert.Equal(t, int64(len(data)), p.done)
+	assert.Equal(t, int64(len(data)), p.total)
+
+	require.NoError(t, ft.Close())
+	assert.Equal(t, data, string(ft.Uploaded(key)))
+}
+
+// reader hides any methods other than Read.
+type reader struct {
+	io.Reader
+}
+
+func TestFileUploadResume(t *testing.T) {
+	data := "hello world"
+	tests := map[string]io.Reader{
+		"seeker": strings.NewReader(data),
+		"reader": reader{strings.NewReader(data)},
+	}
+
+	for name, r := range tests {
+		t.Run(name, func(t *testing.T) {
+			c, s, ft, done := newFileTransferTest(t)
+			defer done()
+
+			const key = "resume0000000000"
+			ft.AddUpload(key)
+			s.setResponse("ftinitupload", fmt.Sprintf("clientftfid=1 serverftfid=6 ftkey=%s port=%d seekpos=6", key, ft.Port))
+
+			var p progress
+			err := c.Server.FileUpload(5, "/file.txt", r, int64(len(data)), &FileTransferOptions{
+				Resume:   true,
+				Progress: p.update,
+			})
+			require.NoError(t, err)
+			assert.Equal(t, int64(len(data)), p.done)
+
+			require.NoError(t, ft.Close())
+			assert.Equal(t, "world", string(ft.Uploaded(key)))
+		})
+	}
+}
+
+func TestFileUploadStatus(t *testing.T) {
+	c, s, _, done := newFileTransferTest(t)
+	defer done()
+
+	s.setResponse("ftinitupload", `clientftfid=1 status=2050 msg=file\salready\sexists`)
+	err := c.Server.FileUpload(5, "/file.txt", strings.NewReader("x"), 1, nil)
+
+	var e *Error
+	if assert.True(t, errors.As(err, &e)) {
+		assert.Equal(t, 2050, e.ID)
+		assert.Equal(t, "file already exists", e.Msg)
+	}
+}
+
+func TestFileDownload(t *testing.T) {
+	c, s, ft, done := newFileTransferTest(t)
+	defer done()
+
+	const key = "download00000000"
+	data := []byte("hello world")
+	ft.AddDownload(key, data)
+	s.setResponse("ftinitdownload", fmt.Sprintf("clientftfid=1 serverftfid=7 ftkey=%s port=%d size=%d", key, ft.Port, len(data)))
+
+	var buf bytes.Buffer
+	var p progress
+	n, err := c.Server.FileDownload(5, "/file.txt", &buf, &FileTransferOptions{Progress: p.update})
+	require.NoError(t, err)
+	assert.Equal(t, int64(len(data)), n)
+	assert.Equal(t, data, buf.Bytes())
+	assert.Equal(t, int64(len(data)), p.done)
+	assert.Equal(t, int64(len(data)), p.total)
+
+	received := s.Received()
+	assert.Regexp(t, `^ftinitdownload clientftfid=\d+ name=\\/file.txt cid=5 cpw= seekpos=0$`, received[len(received)-1])
+}
+
+func TestFileDownloadOffset(t *testing.T) {
+	c, s, ft, done := newFileTransferTest(t)
+	defer done()
+
+	const key = "offset0000000000"
+	data := []byte("hello world")
+	ft.AddDownload(key, data[6:])
+	s.setResponse("ftinitdownload", fmt.Sprintf("clientftfid=1 serverftfid=7 ftkey=%s port=%d size=%d", key, ft.Port, len(data)))
+
+	var buf bytes.Buffer
+	var p progress
+	n, err := c.Server.FileDownload(5, "/file.txt", &buf, &FileTransferOptions{
+		Password: "pw",
+		Offset:   6,
+		Progress: p.update,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, int64(5), n)
+	assert.Equal(t, "world", buf.String())
+	assert.Equal(t, int64(len(data)), p.done)
+
+	received := s.Received()
+	assert.Regexp(t, `^ftinitdownload clientftfid=\d+ name=\\/file.txt cid=5 cpw=pw seekpos=6$`, received[len(received)-1])
+}
+
+func TestFileDownloadShort(t *testing.T) {
+	c, s, ft, done := newFileTransferTest(t)
+	defer done()
+
+	const key = "short00000000000"
+	ft.AddDownload(key, []byte("hello"))
+	s.setResponse("ftinitdownload", fmt.Sprintf("clientftfid=1 serverftfid=7 ftkey=%s port=%d size=11", key, ft.Port))
+
+	var buf bytes.Buffer
+	_, err := c.Server.FileDownload(5, "/file.txt", &buf, nil)
+	assert.True(t, errors.Is(err, io.EOF))
+}
+
+func TestFileDownloadCancelled(t *testing.T) {
+	c, s, ft, done := newFileTransferTest(t)
+	defer done()
+
+	s.setResponse("ftinitdownload", fmt.Sprintf("clientftfid=1 serverftfid=7 ftkey=x port=%d size=11", ft.Port))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err := c.Server.FileDownloadContext(ctx, 5, "/file.txt", &bytes.Buffer{}, nil)
+	assert.Error(t, err)
+}
+
+func TestFileTransferAddr(t *testing.T) {
+	s := &ServerMethods{Client: &Client{addr: "example.com"}}
+
+	tests := map[string]struct {
+		ip       string
+		expected string
+	}{
+		"none":        {ip: "", expected: "example.com:30033"},
+		"unspecified": {ip: "0.0.0.0,::", expected: "example.com:30033"},
+		"specific":    {ip: "0.0.0.0, 10.0.0.1", expected: "10.0.0.1:30033"},
+		"ipv6":        {ip: "::1", expected: "[::1]:30033"},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			addr, err := s.fileTransferAddr(&FileTransfer{IP: tc.ip, Port: 30033})
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, addr)
+		})
+	}
+}
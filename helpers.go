@@ -49,11 +49,11 @@ func Decode(str string) string {
 	return decoder.Replace(str)
 }
 
-// DecodeResponse decodes a response into a struct.
+// DecodeResponse decodes a response into a struct or, if v is a pointer to
+// a slice, a list of structs. Only lists may span multiple lines, each line
+// containing one or more entries.
 func DecodeResponse(lines []string, v interface{}) error {
-	if len(lines) > 1 {
-		return NewInvalidResponseError("too many lines", lines)
-	} else if len(lines) == 0 {
+	if len(lines) == 0 {
 		return NewInvalidResponseError("no lines", lines)
 	}
 
@@ -68,32 +68,25 @@ func DecodeResponse(lines []string, v interface{}) error {
 		}
 	}
 
-	for _, part := range strings.Split(lines[0], "|") {
+	if len(lines) > 1 && elemType == nil {
+		return NewInvalidResponseError("too many lines", lines)
+	}
+
+	var entries []string
+	for _, l := range lines {
+		entries = append(entries, strings.Split(l, "|")...)
+	}
+
+	for _, part := range entries {
 		for _, val := range strings.Split(part, " ") {
 			parts := strings.SplitN(val, "=", 2)
 			key := Decode(parts[0])
 			if len(parts) == 2 {
-				v := Decode(parts[1])
-				if i, err := strconv.Atoi(v); err != nil {
-					// Only support comma seperated lists
-					// by keyname to avoid incorrect decoding.
-					if key == "client_servergroups" {
-						parts := strings.Split(v, ",")
-						serverGroups := make([]int, len(parts))
-						for i, s := range parts {
-							group, err := strconv.Atoi(s)
-							if err != nil {
-								return fmt.Errorf("decode server group: %w", err)
-							}
-							serverGroups[i] = group
-						}
-						input[key] = serverGroups
-					} else {
-						input[key] = v
-					}
-				} else {
-					input[key] = i
+				v, err := decodeValue(key, Decode(parts[1]))
+				if err != nil {
+					return err
 				}
+				input[key] = v
 			} else {
 				input[key] = ""
 			}
@@ -118,13 +111,46 @@ func DecodeResponse(lines []string, v interface{}) error {
 	return decodeMap(input, v)
 }
 
+// rawValue is a value converted by decodeValue which retains the raw
+// value, so string fields receive it unaltered e.g. "007" not "7".
+type rawValue struct {
+	raw string
+	val interface{}
+}
+
+// decodeValue returns the value v for key converted to the type
+// expected by decodeMap.
+func decodeValue(key, v string) (interface{}, error) {
+	i, err := strconv.Atoi(v)
+	if err == nil {
+		return rawValue{raw: v, val: i}, nil
+	}
+
+	// Only support comma seperated lists
+	// by keyname to avoid incorrect decoding.
+	if key == "client_servergroups" {
+		parts := strings.Split(v, ",")
+		serverGroups := make([]int, len(parts))
+		for i, s := range parts {
+			group, err := strconv.Atoi(s)
+			if err != nil {
+				return nil, fmt.Errorf("decode server group: %w", err)
+			}
+			serverGroups[i] = group
+		}
+		return serverGroups, nil
+	}
+
+	return v, nil
+}
+
 // decodeMap decodes input into r.
 func decodeMap(d map[string]interface{}, r interface{}) error {
 	cfg := &mapstructure.DecoderConfig{
 		WeaklyTypedInput: true,
 		TagName:          "ms",
 		Result:           r,
-		DecodeHook:       timeHookFunc,
+		DecodeHook:       decodeHookFunc,
 	}
 	dec, err := mapstructure.NewDecoder(cfg)
 	if err != nil {
@@ -163,6 +189,13 @@ func decodeSlice(elemType reflect.Type, slice reflect.Value, input map[string]in
 			OnlineClientVoice:  &OnlineClientVoice{},
 		}
 		v.Interface().(*OnlineClient).OnlineClientExt = ext
+	case *Channel:
+		ext := &ChannelExt{
+			ChannelFlags:  &ChannelFlags{},
+			ChannelVoice:  &ChannelVoice{},
+			ChannelLimits: &ChannelLimits{},
+		}
+		v.Interface().(*Channel).ChannelExt = ext
 	}
 
 	if err := decodeMap(input, v.Interface()); err != nil {
@@ -198,6 +231,28 @@ func decodeSlice(elemType reflect.Type, slice reflect.Value, input map[string]in
 		if *ext == emptyExt {
 			v.Interface().(*OnlineClient).OnlineClientExt = nil
 		}
+	case *Channel:
+		ext := v.Interface().(*Channel).ChannelExt
+		emptyExt := ChannelExt{}
+		emptyExtFlags := ChannelFlags{}
+		emptyExtVoice := ChannelVoice{}
+		emptyExtLimits := ChannelLimits{}
+
+		if *ext.ChannelFlags == emptyExtFlags {
+			v.Interface().(*Channel).ChannelExt.ChannelFlags = nil
+		}
+
+		if *ext.ChannelVoice == emptyExtVoice {
+			v.Interface().(*Channel).ChannelExt.ChannelVoice = nil
+		}
+
+		if *ext.ChannelLimits == emptyExtLimits {
+			v.Interface().(*Channel).ChannelExt.ChannelLimits = nil
+		}
+
+		if *ext == emptyExt {
+			v.Interface().(*Channel).ChannelExt = nil
+		}
 	}
 
 	if elemType.Kind() == reflect.Struct {
@@ -210,6 +265,24 @@ func decodeSlice(elemType reflect.Type, slice reflect.Value, input map[string]in
 
 var timeType = reflect.TypeOf(time.Time{})
 
+// decodeHookFunc supports decoding values returned by decodeValue, using
+// the raw value for string fields, and decoding to time.
+func decodeHookFunc(from reflect.Type, to reflect.Type, data interface{}) (interface{}, error) {
+	if v, ok := data.(rawValue); ok {
+		t := to
+		for t.Kind() == reflect.Ptr {
+			t = t.Elem()
+		}
+		if t.Kind() == reflect.String {
+			return v.raw, nil
+		}
+		data = v.val
+		from = reflect.TypeOf(data)
+	}
+
+	return timeHookFunc(from, to, data)
+}
+
 // timeHookFunc supports decoding to time.
 func timeHookFunc(from reflect.Type, to reflect.Type, data interface{}) (interface{}, error) {
 	// Decode time.Time
@@ -234,3 +307,12 @@ func timeHookFunc(from reflect.Type, to reflect.Type, data interface{}) (interfa
 
 	return data, nil
 }
+
+// idGroup returns an ArgGroup of key arguments for ids.
+func idGroup(key string, ids []int) *ArgGroup {
+	args := make([]CmdArg, len(ids))
+	for i, id := range ids {
+		args[i] = NewArg(key, id)
+	}
+	return NewArgGroup(args...)
+}
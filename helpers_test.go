@@ -2,8 +2,10 @@ package ts3
 
 import (
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestEncodeDecode(t *testing.T) {
@@ -18,6 +20,44 @@ type testResp struct {
 	Valid    bool
 }
 
+func TestDecodeResponseNumericStrings(t *testing.T) {
+	r := &struct {
+		Name    string    `ms:"name"`
+		Msg     *string   `ms:"msg"`
+		ID      int       `ms:"id"`
+		Valid   bool      `ms:"valid"`
+		Created time.Time `ms:"created"`
+	}{}
+	require.NoError(t, DecodeResponse([]string{`name=007 msg=+1 id=007 valid=1 created=1259147468`}, r))
+	assert.Equal(t, "007", r.Name)
+	if assert.NotNil(t, r.Msg) {
+		assert.Equal(t, "+1", *r.Msg)
+	}
+	assert.Equal(t, 7, r.ID)
+	assert.True(t, r.Valid)
+	assert.Equal(t, time.Unix(1259147468, 0), r.Created)
+
+	m := map[string]interface{}{}
+	require.NoError(t, DecodeResponse([]string{`name=-0 id=7`}, &m))
+	assert.Equal(t, map[string]interface{}{"name": 0, "id": 7}, m)
+
+	n := Notification{Type: EventTextMessage, Data: map[string]string{"msg": "-0", "invokername": "007"}}
+	e, err := n.Event()
+	require.NoError(t, err)
+	if tm, ok := e.(*TextMessage); assert.True(t, ok) {
+		assert.Equal(t, "-0", tm.Message)
+		assert.Equal(t, "007", tm.InvokerName)
+	}
+}
+
+func TestDecodeResponseMultiLine(t *testing.T) {
+	var r []*testResp
+	require.NoError(t, DecodeResponse([]string{"id=1|id=2", "id=3"}, &r))
+	if assert.Len(t, r, 3) {
+		assert.Equal(t, 3, r[2].ID)
+	}
+}
+
 func TestDecodeResponse(t *testing.T) {
 	r := &testResp{}
 	expected := &testResp{
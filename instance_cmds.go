@@ -0,0 +1,245 @@
+package ts3
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// InstanceFloodCommands is the number of commands a query client can send
+// within InstanceFloodTime before being banned for flooding.
+// It can be passed to InstanceEdit.
+type InstanceFloodCommands int
+
+// ArgString implements CmdArg.
+func (c InstanceFloodCommands) ArgString() string {
+	return NewArg("serverinstance_serverquery_flood_commands", int(c)).ArgString()
+}
+
+// InstanceFloodTime is the period over which InstanceFloodCommands applies.
+// It's sent in whole seconds, so negative values and those under a second
+// are rejected by InstanceEdit.
+// It can be passed to InstanceEdit.
+type InstanceFloodTime time.Duration
+
+// ArgString implements CmdArg.
+func (t InstanceFloodTime) ArgString() string {
+	return NewArg("serverinstance_serverquery_flood_time", int(time.Duration(t).Seconds())).ArgString()
+}
+
+// InstanceBanTime is how long query clients are banned for flooding.
+// It's sent in whole seconds, so negative values and those under a second
+// are rejected by InstanceEdit.
+// It can be passed to InstanceEdit.
+type InstanceBanTime time.Duration
+
+// ArgString implements CmdArg.
+func (t InstanceBanTime) ArgString() string {
+	return NewArg("serverinstance_serverquery_ban_time", int(time.Duration(t).Seconds())).ArgString()
+}
+
+// InstanceMaxDownloadBandwidth is the maximum total download bandwidth of
+// the instance in bytes per second.
+// It can be passed to InstanceEdit.
+type InstanceMaxDownloadBandwidth uint64
+
+// ArgString implements CmdArg.
+func (b InstanceMaxDownloadBandwidth) ArgString() string {
+	return NewArg("serverinstance_max_download_total_bandwidth", uint64(b)).ArgString()
+}
+
+// InstanceMaxUploadBandwidth is the maximum total upload bandwidth of
+// the instance in bytes per second.
+// It can be passed to InstanceEdit.
+type InstanceMaxUploadBandwidth uint64
+
+// ArgString implements CmdArg.
+func (b InstanceMaxUploadBandwidth) ArgString() string {
+	return NewArg("serverinstance_max_upload_total_bandwidth", uint64(b)).ArgString()
+}
+
+// InstanceGuestServerQueryGroup is the server group of query clients which
+// haven't logged in.
+// It can be passed to InstanceEdit.
+type InstanceGuestServerQueryGroup int
+
+// ArgString implements CmdArg.
+func (g InstanceGuestServerQueryGroup) ArgString() string {
+	return NewArg("serverinstance_guest_serverquery_group", int(g)).ArgString()
+}
+
+// InstanceTemplateServerAdminGroup is the template server group used for
+// the admin group of new virtual servers.
+// It can be passed to InstanceEdit.
+type InstanceTemplateServerAdminGroup int
+
+// ArgString implements CmdArg.
+func (g InstanceTemplateServerAdminGroup) ArgString() string {
+	return NewArg("serverinstance_template_serveradmin_group", int(g)).ArgString()
+}
+
+// InstanceTemplateServerDefaultGroup is the template server group used for
+// the default group of new virtual servers.
+// It can be passed to InstanceEdit.
+type InstanceTemplateServerDefaultGroup int
+
+// ArgString implements CmdArg.
+func (g InstanceTemplateServerDefaultGroup) ArgString() string {
+	return NewArg("serverinstance_template_serverdefault_group", int(g)).ArgString()
+}
+
+// InstanceTemplateChannelAdminGroup is the template channel group used for
+// the admin channel group of new virtual servers.
+// It can be passed to InstanceEdit.
+type InstanceTemplateChannelAdminGroup int
+
+// ArgString implements CmdArg.
+func (g InstanceTemplateChannelAdminGroup) ArgString() string {
+	return NewArg("serverinstance_template_channeladmin_group", int(g)).ArgString()
+}
+
+// InstanceTemplateChannelDefaultGroup is the template channel group used for
+// the default channel group of new virtual servers.
+// It can be passed to InstanceEdit.
+type InstanceTemplateChannelDefaultGroup int
+
+// ArgString implements CmdArg.
+func (g InstanceTemplateChannelDefaultGroup) ArgString() string {
+	return NewArg("serverinstance_template_channeldefault_group", int(g)).ArgString()
+}
+
+// InstancePendingConnectionsPerIP is the maximum number of pending
+// connections from a single IP, zero means unlimited.
+// It can be passed to InstanceEdit.
+type InstancePendingConnectionsPerIP int
+
+// ArgString implements CmdArg.
+func (p InstancePendingConnectionsPerIP) ArgString() string {
+	return NewArg("serverinstance_pending_connections_per_ip", int(p)).ArgString()
+}
+
+// InstanceEdit changes the properties of the instance e.g.
+// InstanceEdit(InstanceFloodCommands(100), InstanceBanTime(time.Minute)).
+func (s *ServerMethods) InstanceEdit(props ...CmdArg) error {
+	return s.InstanceEditContext(context.Background(), props...)
+}
+
+// InstanceEditContext changes the properties of the instance.
+func (s *ServerMethods) InstanceEditContext(ctx context.Context, props ...CmdArg) error {
+	for _, p := range props {
+		var d time.Duration
+		switch p := p.(type) {
+		case InstanceFloodTime:
+			d = time.Duration(p)
+		case InstanceBanTime:
+			d = time.Duration(p)
+		default:
+			continue
+		}
+
+		if d < 0 || (d > 0 && d < time.Second) {
+			return fmt.Errorf("instance edit: invalid duration %v", d)
+		}
+	}
+
+	_, err := s.ExecCmdContext(ctx, NewCmd("instanceedit").WithArgs(props...))
+	return err
+}
+
+// HostInfo represents information about the host of a TeamSpeak 3 instance.
+type HostInfo struct {
+	Uptime                        int       `ms:"instance_uptime"` // In seconds.
+	Timestamp                     time.Time `ms:"host_timestamp_utc"`
+	VirtualServersRunning         int       `ms:"virtualservers_running_total"`
+	MaxClients                    int       `ms:"virtualservers_total_maxclients"`
+	ClientsOnline                 int       `ms:"virtualservers_total_clients_online"`
+	ChannelsOnline                int       `ms:"virtualservers_total_channels_online"`
+	FileTransferBandwidthSent     uint64    `ms:"connection_filetransfer_bandwidth_sent"`
+	FileTransferBandwidthReceived uint64    `ms:"connection_filetransfer_bandwidth_received"`
+	FileTransferTotalSent         uint64    `ms:"connection_filetransfer_bytes_sent_total"`
+	FileTransferTotalReceived     uint64    `ms:"connection_filetransfer_bytes_received_total"`
+	PacketsSentTotal              uint64    `ms:"connection_packets_sent_total"`
+	PacketsReceivedTotal          uint64    `ms:"connection_packets_received_total"`
+	BytesSentTotal                uint64    `ms:"connection_bytes_sent_total"`
+	BytesReceivedTotal            uint64    `ms:"connection_bytes_received_total"`
+	BandwidthSentLastSecond       uint64    `ms:"connection_bandwidth_sent_last_second_total"`
+	BandwidthReceivedLastSecond   uint64    `ms:"connection_bandwidth_received_last_second_total"`
+	BandwidthSentLastMinute       uint64    `ms:"connection_bandwidth_sent_last_minute_total"`
+	BandwidthReceivedLastMinute   uint64    `ms:"connection_bandwidth_received_last_minute_total"`
+}
+
+// HostInfo returns information about the host of the instance, including
+// totals across all virtual servers.
+func (s *ServerMethods) HostInfo() (*HostInfo, error) {
+	return s.HostInfoContext(context.Background())
+}
+
+// HostInfoContext returns information about the host of the instance.
+func (s *ServerMethods) HostInfoContext(ctx context.Context) (*HostInfo, error) {
+	r := &HostInfo{}
+	if _, err := s.ExecCmdContext(ctx, NewCmd("hostinfo").WithResponse(r)); err != nil {
+		return nil, err
+	}
+
+	return r, nil
+}
+
+// BindingSubsystem is a subsystem of the instance which listens on network
+// addresses. It can be passed to BindingList.
+type BindingSubsystem string
+
+const (
+	// BindingVoice is the voice subsystem.
+	BindingVoice BindingSubsystem = "voice"
+	// BindingQuery is the ServerQuery subsystem.
+	BindingQuery BindingSubsystem = "query"
+	// BindingFileTransfer is the file transfer subsystem.
+	BindingFileTransfer BindingSubsystem = "filetransfer"
+)
+
+// BindingList returns the IP addresses the subsystem of the instance is
+// bound to. If subsystem is empty the server default, the voice subsystem,
+// is used.
+func (s *ServerMethods) BindingList(subsystem BindingSubsystem) ([]string, error) {
+	return s.BindingListContext(context.Background(), subsystem)
+}
+
+// BindingListContext returns the IP addresses the subsystem of the instance is bound to.
+func (s *ServerMethods) BindingListContext(ctx context.Context, subsystem BindingSubsystem) ([]string, error) {
+	cmd := NewCmd("bindinglist")
+	if subsystem != "" {
+		cmd.WithArgs(NewArg("subsystem", string(subsystem)))
+	}
+
+	var r []*struct {
+		IP string `ms:"ip"`
+	}
+	if _, err := s.ExecCmdContext(ctx, cmd.WithResponse(&r)); err != nil {
+		return nil, err
+	}
+
+	ips := make([]string, len(r))
+	for i, v := range r {
+		ips[i] = v.IP
+	}
+
+	return ips, nil
+}
+
+// ServerProcessStop stops the instance, shutting down all its virtual
+// servers. If reason isn't empty it's sent to connected clients.
+// The connection is closed by the server once the command completes.
+func (s *ServerMethods) ServerProcessStop(reason string) error {
+	return s.ServerProcessStopContext(context.Background(), reason)
+}
+
+// ServerProcessStopContext stops the instance, shutting down all its virtual servers.
+func (s *ServerMethods) ServerProcessStopContext(ctx context.Context, reason string) error {
+	cmd := NewCmd("serverprocessstop")
+	if reason != "" {
+		cmd.WithArgs(NewArg("reasonmsg", reason))
+	}
+
+	_, err := s.ExecCmdContext(ctx, cmd)
+	return err
+}
@@ -0,0 +1,145 @@
+package ts3
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCmdsInstance(t *testing.T) {
+	s := newServer(t)
+	defer func() {
+		assert.NoError(t, s.Close())
+	}()
+
+	c, err := NewClient(s.Addr, Timeout(time.Second*2))
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	defer func() {
+		assert.NoError(t, c.Close())
+	}()
+
+	testCmdsInstance(t, c, s)
+}
+
+func TestCmdsInstanceSSH(t *testing.T) {
+	s := newServer(t, useSSH())
+	defer func() {
+		assert.NoError(t, s.Close())
+	}()
+
+	c, err := NewClient(s.Addr, Timeout(time.Second*2), SSH(sshClientTestConfig))
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	defer func() {
+		assert.NoError(t, c.Close())
+	}()
+
+	testCmdsInstance(t, c, s)
+}
+func testCmdsInstance(t *testing.T, c *Client, s *server) {
+	t.Helper()
+	lastCmd := func() string {
+		received := s.Received()
+		return received[len(received)-1]
+	}
+
+	instanceedit := func(t *testing.T) {
+		t.Helper()
+		assert.NoError(t, c.Server.InstanceEdit(
+			InstanceFloodCommands(100),
+			InstanceFloodTime(time.Second*5),
+			InstanceBanTime(time.Minute*10),
+			InstanceMaxDownloadBandwidth(1000000),
+			InstanceMaxUploadBandwidth(2000000),
+			InstanceGuestServerQueryGroup(1),
+			InstanceTemplateServerAdminGroup(3),
+			InstanceTemplateServerDefaultGroup(5),
+			InstanceTemplateChannelAdminGroup(1),
+			InstanceTemplateChannelDefaultGroup(4),
+			InstancePendingConnectionsPerIP(10),
+		))
+		assert.Equal(t, "instanceedit serverinstance_serverquery_flood_commands=100 serverinstance_serverquery_flood_time=5 "+
+			"serverinstance_serverquery_ban_time=600 serverinstance_max_download_total_bandwidth=1000000 "+
+			"serverinstance_max_upload_total_bandwidth=2000000 serverinstance_guest_serverquery_group=1 "+
+			"serverinstance_template_serveradmin_group=3 serverinstance_template_serverdefault_group=5 "+
+			"serverinstance_template_channeladmin_group=1 serverinstance_template_channeldefault_group=4 "+
+			"serverinstance_pending_connections_per_ip=10", lastCmd())
+
+		n := len(s.Received())
+		assert.Error(t, c.Server.InstanceEdit(InstanceFloodTime(time.Millisecond*500)))
+		assert.Error(t, c.Server.InstanceEdit(InstanceBanTime(-time.Minute)))
+		assert.Len(t, s.Received(), n)
+	}
+
+	hostinfo := func(t *testing.T) {
+		t.Helper()
+		hi, err := c.Server.HostInfo()
+		if !assert.NoError(t, err) {
+			return
+		}
+
+		expected := &HostInfo{
+			Uptime:                      1903,
+			Timestamp:                   time.Unix(1259337246, 0),
+			VirtualServersRunning:       1,
+			MaxClients:                  32,
+			ClientsOnline:               1,
+			ChannelsOnline:              2,
+			FileTransferTotalSent:       617,
+			PacketsSentTotal:            926413,
+			PacketsReceivedTotal:        650335,
+			BytesSentTotal:              92911395,
+			BytesReceivedTotal:          61940731,
+			BandwidthSentLastSecond:     81,
+			BandwidthReceivedLastSecond: 83,
+			BandwidthSentLastMinute:     92,
+			BandwidthReceivedLastMinute: 88,
+		}
+		assert.Equal(t, expected, hi)
+	}
+
+	bindinglist := func(t *testing.T) {
+		t.Helper()
+		ips, err := c.Server.BindingList("")
+		if !assert.NoError(t, err) {
+			return
+		}
+		assert.Equal(t, []string{"0.0.0.0", "::"}, ips)
+		assert.Equal(t, "bindinglist", lastCmd())
+
+		ips, err = c.Server.BindingList(BindingQuery)
+		if !assert.NoError(t, err) {
+			return
+		}
+		assert.Equal(t, []string{"127.0.0.1"}, ips)
+	}
+
+	serverprocessstop := func(t *testing.T) {
+		t.Helper()
+		assert.NoError(t, c.Server.ServerProcessStop(""))
+		assert.Equal(t, "serverprocessstop", lastCmd())
+
+		assert.NoError(t, c.Server.ServerProcessStop("maintenance"))
+		assert.Equal(t, "serverprocessstop reasonmsg=maintenance", lastCmd())
+	}
+
+	tests := []struct {
+		name string
+		f    func(t *testing.T)
+	}{
+		{"instanceedit", instanceedit},
+		{"hostinfo", hostinfo},
+		{"bindinglist", bindinglist},
+		{"serverprocessstop", serverprocessstop},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, tc.f)
+	}
+}
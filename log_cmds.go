@@ -0,0 +1,359 @@
+package ts3
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// MaxLogViewLines is the maximum number of lines returned by LogView.
+const MaxLogViewLines = 100
+
+// logTimeLayout is the layout of the timestamp of a log entry.
+const logTimeLayout = "2006-01-02 15:04:05.999999"
+
+// LogLevel is the severity of a log entry.
+type LogLevel int
+
+const (
+	// LogLevelUnknown is the level of entries with an unrecognised level.
+	LogLevelUnknown LogLevel = iota - 1
+	// LogLevelCritical is a critical error.
+	LogLevelCritical
+	// LogLevelError is an error.
+	LogLevelError
+	// LogLevelWarning is a warning.
+	LogLevelWarning
+	// LogLevelDebug is a debug message.
+	LogLevelDebug
+	// LogLevelInfo is an informational message.
+	LogLevelInfo
+	// LogLevelDevel is a development message.
+	LogLevelDevel
+)
+
+// logLevels are the names of the log levels as they appear in the log.
+var logLevels = []string{"CRITICAL", "ERROR", "WARNING", "DEBUG", "INFO", "DEVEL"}
+
+// String implements fmt.Stringer.
+func (l LogLevel) String() string {
+	if l < LogLevelCritical || int(l) >= len(logLevels) {
+		return "UNKNOWN"
+	}
+	return logLevels[l]
+}
+
+// parseLogLevel returns the LogLevel named s.
+func parseLogLevel(s string) LogLevel {
+	for i, n := range logLevels {
+		if n == s {
+			return LogLevel(i)
+		}
+	}
+	return LogLevelUnknown
+}
+
+// LogEntry represents an entry in a server or instance log.
+type LogEntry struct {
+	Timestamp time.Time
+	Level     LogLevel
+	Channel   string // The component which logged the entry e.g. "VirtualServer".
+	ServerID  int    // Zero for instance entries.
+	Message   string
+
+	line string
+}
+
+// String returns the entry as it appears in the log.
+func (e *LogEntry) String() string {
+	return e.line
+}
+
+// parseLogEntry parses line, a log entry in the form:
+// "2009-12-28 12:55:19.937235|INFO    |VirtualServer |  1| message".
+// Lines which don't match the form are returned with only Message set.
+func parseLogEntry(line string) *LogEntry {
+	e := &LogEntry{Level: LogLevelUnknown, Message: line, line: line}
+	parts := strings.SplitN(line, "|", 5)
+	if len(parts) != 5 {
+		return e
+	}
+
+	ts, err := time.ParseInLocation(logTimeLayout, strings.TrimSpace(parts[0]), time.UTC)
+	if err != nil {
+		return e
+	}
+
+	e.Timestamp = ts
+	e.Level = parseLogLevel(strings.TrimSpace(parts[1]))
+	e.Channel = strings.TrimSpace(parts[2])
+	e.ServerID, _ = strconv.Atoi(strings.TrimSpace(parts[3]))
+	e.Message = strings.TrimSpace(parts[4])
+
+	return e
+}
+
+// LogViewOptions configures LogView.
+type LogViewOptions struct {
+	// Lines is the number of entries to return, up to MaxLogViewLines.
+	// If zero the server default is used.
+	Lines int
+
+	// Reverse returns the newest entry first.
+	Reverse bool
+
+	// Instance returns entries from the instance log instead of the log
+	// of the selected server.
+	Instance bool
+
+	// BeginPos is the position in the log from which to read backwards,
+	// as returned in LastPos by a previous call. If zero the entries are
+	// read from the end of the log.
+	BeginPos int64
+}
+
+// LogPage represents a page of log entries returned by LogView.
+type LogPage struct {
+	Entries []*LogEntry
+
+	// LastPos is the position in the log of the oldest entry returned,
+	// which can be passed as LogViewOptions.BeginPos to read older entries.
+	// It's zero once the start of the log has been reached.
+	LastPos int64
+
+	// FileSize is the size of the log in bytes.
+	FileSize int64
+}
+
+// LogView returns entries from the log of the selected server, or the
+// instance log if opts.Instance is set.
+// opts may be nil.
+func (s *ServerMethods) LogView(opts *LogViewOptions) (*LogPage, error) {
+	return s.LogViewContext(context.Background(), opts)
+}
+
+// LogViewContext returns entries from the log of the selected server.
+func (s *ServerMethods) LogViewContext(ctx context.Context, opts *LogViewOptions) (*LogPage, error) {
+	if opts == nil {
+		opts = &LogViewOptions{}
+	}
+
+	if opts.Lines < 0 || opts.Lines > MaxLogViewLines {
+		return nil, fmt.Errorf("log view: invalid lines %d", opts.Lines)
+	}
+
+	args := []CmdArg{
+		NewArg("reverse", opts.Reverse),
+		NewArg("instance", opts.Instance),
+	}
+	if opts.Lines > 0 {
+		args = append(args, NewArg("lines", opts.Lines))
+	}
+	if opts.BeginPos > 0 {
+		args = append(args, NewArg("begin_pos", opts.BeginPos))
+	}
+
+	lines, err := s.ExecCmdContext(ctx, NewCmd("logview").WithArgs(args...))
+	if err != nil {
+		if isEmptyResult(err) {
+			return &LogPage{}, nil
+		}
+		return nil, err
+	}
+
+	page := &LogPage{}
+	if len(lines) == 0 {
+		return page, nil
+	}
+
+	var r []*struct {
+		LastPos  int64  `ms:"last_pos"`
+		FileSize int64  `ms:"file_size"`
+		Line     string `ms:"l"`
+	}
+	if err := DecodeResponse([]string{strings.Join(lines, "|")}, &r); err != nil {
+		return nil, err
+	}
+
+	for i, v := range r {
+		// Only the first entry includes the position and size.
+		if i == 0 {
+			page.LastPos = v.LastPos
+			page.FileSize = v.FileSize
+		}
+		if v.Line != "" {
+			page.Entries = append(page.Entries, parseLogEntry(v.Line))
+		}
+	}
+
+	return page, nil
+}
+
+// LogIterator iterates backwards over a log, newest entry first,
+// requesting the entries a page at a time.
+type LogIterator struct {
+	s     *ServerMethods
+	ctx   context.Context
+	opts  LogViewOptions
+	page  []*LogEntry
+	entry *LogEntry
+	done  bool
+	err   error
+}
+
+// LogIter returns an iterator over the log of the selected server, or the
+// instance log if opts.Instance is set, starting from opts.BeginPos and
+// requesting opts.Lines entries at a time. opts.Reverse is ignored.
+// If opts.Lines is zero MaxLogViewLines is used.
+// opts may be nil.
+func (s *ServerMethods) LogIter(opts *LogViewOptions) *LogIterator {
+	return s.LogIterContext(context.Background(), opts)
+}
+
+// LogIterContext returns an iterator over the log of the selected server.
+func (s *ServerMethods) LogIterContext(ctx context.Context, opts *LogViewOptions) *LogIterator {
+	it := &LogIterator{s: s, ctx: ctx}
+	if opts != nil {
+		it.opts = *opts
+	}
+	it.opts.Reverse = false
+	if it.opts.Lines == 0 {
+		it.opts.Lines = MaxLogViewLines
+	}
+
+	return it
+}
+
+// Next advances the iterator to the next older entry, which is then
+// available from Entry. It returns false when the start of the log has
+// been reached or an error occurred, which is then available from Err.
+func (it *LogIterator) Next() bool {
+	if len(it.page) == 0 {
+		if it.done {
+			it.entry = nil
+			return false
+		}
+
+		var page *LogPage
+		page, it.err = it.s.LogViewContext(it.ctx, &it.opts)
+		if it.err != nil || len(page.Entries) == 0 {
+			it.done = true
+			it.entry = nil
+			return false
+		}
+
+		it.page = page.Entries
+		it.done = page.LastPos <= 0 || (it.opts.BeginPos > 0 && page.LastPos >= it.opts.BeginPos)
+		it.opts.BeginPos = page.LastPos
+	}
+
+	// Pages are returned oldest entry first.
+	it.entry = it.page[len(it.page)-1]
+	it.page = it.page[:len(it.page)-1]
+
+	return true
+}
+
+// Entry returns the current entry.
+func (it *LogIterator) Entry() *LogEntry {
+	return it.entry
+}
+
+// Err returns the error, if any, which stopped the iteration.
+func (it *LogIterator) Err() error {
+	return it.err
+}
+
+// LogFollow calls fn with the newest opts.Lines entries of the log of the
+// selected server, or the instance log if opts.Instance is set, oldest
+// first, then polls the log every interval calling fn with each new entry,
+// in the same way as tail -f.
+// opts.Reverse and opts.BeginPos are ignored and if opts.Lines is zero
+// MaxLogViewLines is used. If more than opts.Lines entries are added
+// between polls the older ones are skipped.
+// It returns when ctx is done or if fn or LogView returns an error.
+// opts may be nil.
+func (s *ServerMethods) LogFollow(ctx context.Context, interval time.Duration, opts *LogViewOptions, fn func(*LogEntry) error) error {
+	if interval <= 0 {
+		return fmt.Errorf("log follow: invalid interval %v", interval)
+	}
+
+	var o LogViewOptions
+	if opts != nil {
+		o = *opts
+	}
+	o.Reverse = false
+	o.BeginPos = 0
+	if o.Lines == 0 {
+		o.Lines = MaxLogViewLines
+	}
+
+	t := time.NewTicker(interval)
+	defer t.Stop()
+
+	var last string
+	size := int64(-1)
+	for {
+		page, err := s.LogViewContext(ctx, &o)
+		if err != nil {
+			return err
+		}
+
+		if page.FileSize != size {
+			for _, e := range newLogEntries(page.Entries, last, page.FileSize < size) {
+				if err := fn(e); err != nil {
+					return err
+				}
+			}
+
+			size = page.FileSize
+			if n := len(page.Entries); n > 0 {
+				last = page.Entries[n-1].line
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-t.C:
+		}
+	}
+}
+
+// newLogEntries returns the entries which follow the entry last in entries.
+// If last isn't found or the log was truncated all entries are returned.
+func newLogEntries(entries []*LogEntry, last string, truncated bool) []*LogEntry {
+	if last == "" || truncated {
+		return entries
+	}
+
+	for i := len(entries) - 1; i >= 0; i-- {
+		if entries[i].line == last {
+			return entries[i+1:]
+		}
+	}
+
+	return entries
+}
+
+// LogAdd writes msg with the given level to the log of the selected server.
+// Only LogLevelError, LogLevelWarning, LogLevelDebug and LogLevelInfo are
+// supported.
+func (s *ServerMethods) LogAdd(level LogLevel, msg string) error {
+	return s.LogAddContext(context.Background(), level, msg)
+}
+
+// LogAddContext writes msg with the given level to the log of the selected server.
+func (s *ServerMethods) LogAddContext(ctx context.Context, level LogLevel, msg string) error {
+	if level < LogLevelError || level > LogLevelInfo {
+		return fmt.Errorf("log add: invalid level %d", level)
+	}
+
+	_, err := s.ExecCmdContext(ctx, NewCmd("logadd").WithArgs(
+		NewArg("loglevel", int(level)),
+		NewArg("logmsg", msg),
+	))
+	return err
+}
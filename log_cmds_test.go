@@ -0,0 +1,233 @@
+package ts3
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCmdsLog(t *testing.T) {
+	s := newServer(t)
+	defer func() {
+		assert.NoError(t, s.Close())
+	}()
+
+	c, err := NewClient(s.Addr, Timeout(time.Second*2))
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	defer func() {
+		assert.NoError(t, c.Close())
+	}()
+
+	testCmdsLog(t, c, s)
+}
+
+func TestCmdsLogSSH(t *testing.T) {
+	s := newServer(t, useSSH())
+	defer func() {
+		assert.NoError(t, s.Close())
+	}()
+
+	c, err := NewClient(s.Addr, Timeout(time.Second*2), SSH(sshClientTestConfig))
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	defer func() {
+		assert.NoError(t, c.Close())
+	}()
+
+	testCmdsLog(t, c, s)
+}
+func testCmdsLog(t *testing.T, c *Client, s *server) {
+	t.Helper()
+	lastCmd := func() string {
+		received := s.Received()
+		return received[len(received)-1]
+	}
+
+	listening := &LogEntry{
+		Timestamp: time.Date(2026, 10, 16, 12, 0, 0, 123456000, time.UTC),
+		Level:     LogLevelInfo,
+		Channel:   "VirtualServer",
+		ServerID:  1,
+		Message:   "listening on 0.0.0.0:9987",
+		line:      "2026-10-16 12:00:00.123456|INFO    |VirtualServer |  1| listening on 0.0.0.0:9987",
+	}
+	license := &LogEntry{
+		Timestamp: time.Date(2026, 10, 16, 12, 0, 1, 0, time.UTC),
+		Level:     LogLevelWarning,
+		Channel:   "Accounting",
+		Message:   "Unable to find valid license key",
+		line:      "2026-10-16 12:00:01|WARNING |Accounting    |   | Unable to find valid license key",
+	}
+
+	logview := func(t *testing.T) {
+		t.Helper()
+		page, err := c.Server.LogView(nil)
+		if !assert.NoError(t, err) {
+			return
+		}
+
+		expected := &LogPage{
+			Entries:  []*LogEntry{listening, license},
+			FileSize: 200,
+		}
+		assert.Equal(t, expected, page)
+		assert.Equal(t, "logview reverse=0 instance=0", lastCmd())
+	}
+
+	logviewOptions := func(t *testing.T) {
+		t.Helper()
+		_, err := c.Server.LogView(&LogViewOptions{Lines: 10, Reverse: true, BeginPos: 50})
+		assert.NoError(t, err)
+		assert.Equal(t, "logview reverse=1 instance=0 lines=10 begin_pos=50", lastCmd())
+	}
+
+	logviewEmpty := func(t *testing.T) {
+		t.Helper()
+		page, err := c.Server.LogView(&LogViewOptions{Lines: 5})
+		if !assert.NoError(t, err) {
+			return
+		}
+		assert.Empty(t, page.Entries)
+	}
+
+	logviewInvalid := func(t *testing.T) {
+		t.Helper()
+		_, err := c.Server.LogView(&LogViewOptions{Lines: MaxLogViewLines + 1})
+		assert.Error(t, err)
+	}
+
+	logiter := func(t *testing.T) {
+		t.Helper()
+		it := c.Server.LogIter(&LogViewOptions{Lines: 1, Instance: true})
+		var msgs []string
+		for it.Next() {
+			msgs = append(msgs, it.Entry().Message)
+		}
+		assert.NoError(t, it.Err())
+		assert.Equal(t, []string{"started", "starting"}, msgs)
+		assert.Nil(t, it.Entry())
+		assert.False(t, it.Next())
+	}
+
+	logfollow := func(t *testing.T) {
+		t.Helper()
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+		defer cancel()
+
+		var msgs []string
+		err := c.Server.LogFollow(ctx, time.Millisecond*10, &LogViewOptions{Lines: 2}, func(e *LogEntry) error {
+			msgs = append(msgs, e.Message)
+			if len(msgs) == 2 {
+				s.setResponse("logview reverse=0 instance=0 lines=2", `last_pos=0 file_size=250 l=`+encoder.Replace(license.line)+`|l=2026-10-16\s12:00:02\pINFO\s\s\s\s\pVirtualServer\s\p\s\s1\p\sclient\sconnected`)
+			}
+			if len(msgs) == 3 {
+				cancel()
+			}
+			return nil
+		})
+		assert.True(t, errors.Is(err, context.Canceled))
+		assert.Equal(t, []string{listening.Message, license.Message, "client connected"}, msgs)
+	}
+
+	logfollowError := func(t *testing.T) {
+		t.Helper()
+		errStop := errors.New("stop")
+		err := c.Server.LogFollow(context.Background(), time.Millisecond*10, nil, func(e *LogEntry) error {
+			return errStop
+		})
+		assert.Equal(t, errStop, err)
+	}
+
+	logadd := func(t *testing.T) {
+		t.Helper()
+		assert.NoError(t, c.Server.LogAdd(LogLevelInfo, "audit entry"))
+		assert.Equal(t, `logadd loglevel=4 logmsg=audit\sentry`, lastCmd())
+		assert.Error(t, c.Server.LogAdd(LogLevelCritical, "audit entry"))
+	}
+
+	tests := []struct {
+		name string
+		f    func(t *testing.T)
+	}{
+		{"logview", logview},
+		{"logview-options", logviewOptions},
+		{"logview-empty", logviewEmpty},
+		{"logview-invalid", logviewInvalid},
+		{"logiter", logiter},
+		{"logfollow", logfollow},
+		{"logfollow-error", logfollowError},
+		{"logadd", logadd},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, tc.f)
+	}
+}
+
+func TestParseLogEntry(t *testing.T) {
+	tests := map[string]struct {
+		line     string
+		expected *LogEntry
+	}{
+		"instance": {
+			line: "2026-10-16 12:00:00.5|ERROR   |ServerLibPriv |   | failed",
+			expected: &LogEntry{
+				Timestamp: time.Date(2026, 10, 16, 12, 0, 0, 500000000, time.UTC),
+				Level:     LogLevelError,
+				Channel:   "ServerLibPriv",
+				Message:   "failed",
+			},
+		},
+		"message-separator": {
+			line: "2026-10-16 12:00:00|DEVEL   |Query         | 12| a|b",
+			expected: &LogEntry{
+				Timestamp: time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC),
+				Level:     LogLevelDevel,
+				Channel:   "Query",
+				ServerID:  12,
+				Message:   "a|b",
+			},
+		},
+		"unknown-level": {
+			line: "2026-10-16 12:00:00|TRACE   |Query         |   | x",
+			expected: &LogEntry{
+				Timestamp: time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC),
+				Level:     LogLevelUnknown,
+				Channel:   "Query",
+				Message:   "x",
+			},
+		},
+		"malformed": {
+			line:     "continued message",
+			expected: &LogEntry{Level: LogLevelUnknown, Message: "continued message"},
+		},
+		"bad-timestamp": {
+			line:     "yesterday|INFO|Query|1|x",
+			expected: &LogEntry{Level: LogLevelUnknown, Message: "yesterday|INFO|Query|1|x"},
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			tc.expected.line = tc.line
+			e := parseLogEntry(tc.line)
+			assert.Equal(t, tc.expected, e)
+			assert.Equal(t, tc.line, e.String())
+		})
+	}
+}
+
+func TestLogLevelString(t *testing.T) {
+	assert.Equal(t, "CRITICAL", LogLevelCritical.String())
+	assert.Equal(t, "INFO", LogLevelInfo.String())
+	assert.Equal(t, "UNKNOWN", LogLevelUnknown.String())
+	assert.Equal(t, "UNKNOWN", LogLevel(42).String())
+}
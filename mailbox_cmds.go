@@ -0,0 +1,92 @@
+package ts3
+
+import (
+	"context"
+	"time"
+)
+
+// Message represents an offline message in the mailbox of the query client.
+type Message struct {
+	ID        int       `ms:"msgid"`
+	SenderUID string    `ms:"cluid"`
+	Subject   string    `ms:"subject"`
+	Message   string    `ms:"message"` // Only populated by MessageGet.
+	Created   time.Time `ms:"timestamp"`
+	Read      bool      `ms:"flag_read"`
+}
+
+// MessageList returns the offline messages in the mailbox of the query client.
+func (s *ServerMethods) MessageList() ([]*Message, error) {
+	return s.MessageListContext(context.Background())
+}
+
+// MessageListContext returns the offline messages in the mailbox of the query client.
+func (s *ServerMethods) MessageListContext(ctx context.Context) ([]*Message, error) {
+	var msgs []*Message
+	if _, err := s.ExecCmdContext(ctx, NewCmd("messagelist").WithResponse(&msgs)); err != nil {
+		if isEmptyResult(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return msgs, nil
+}
+
+// MessageAdd leaves an offline message for the client with the unique
+// identifier uid, which it receives the next time it connects.
+func (s *ServerMethods) MessageAdd(uid, subject, msg string) error {
+	return s.MessageAddContext(context.Background(), uid, subject, msg)
+}
+
+// MessageAddContext leaves an offline message for the client with the unique
+// identifier uid.
+func (s *ServerMethods) MessageAddContext(ctx context.Context, uid, subject, msg string) error {
+	_, err := s.ExecCmdContext(ctx, NewCmd("messageadd").WithArgs(
+		NewArg("cluid", uid),
+		NewArg("subject", subject),
+		NewArg("message", msg),
+	))
+	return err
+}
+
+// MessageGet returns the offline message id, including its content.
+// The server doesn't mark the message as read, use MessageUpdateFlag.
+func (s *ServerMethods) MessageGet(id int) (*Message, error) {
+	return s.MessageGetContext(context.Background(), id)
+}
+
+// MessageGetContext returns the offline message id, including its content.
+func (s *ServerMethods) MessageGetContext(ctx context.Context, id int) (*Message, error) {
+	m := &Message{}
+	if _, err := s.ExecCmdContext(ctx, NewCmd("messageget").WithArgs(NewArg("msgid", id)).WithResponse(m)); err != nil {
+		return nil, err
+	}
+
+	return m, nil
+}
+
+// MessageDel deletes the offline message id.
+func (s *ServerMethods) MessageDel(id int) error {
+	return s.MessageDelContext(context.Background(), id)
+}
+
+// MessageDelContext deletes the offline message id.
+func (s *ServerMethods) MessageDelContext(ctx context.Context, id int) error {
+	_, err := s.ExecCmdContext(ctx, NewCmd("messagedel").WithArgs(NewArg("msgid", id)))
+	return err
+}
+
+// MessageUpdateFlag marks the offline message id as read or unread.
+func (s *ServerMethods) MessageUpdateFlag(id int, read bool) error {
+	return s.MessageUpdateFlagContext(context.Background(), id, read)
+}
+
+// MessageUpdateFlagContext marks the offline message id as read or unread.
+func (s *ServerMethods) MessageUpdateFlagContext(ctx context.Context, id int, read bool) error {
+	_, err := s.ExecCmdContext(ctx, NewCmd("messageupdateflag").WithArgs(
+		NewArg("msgid", id),
+		NewArg("flag", read),
+	))
+	return err
+}
@@ -0,0 +1,117 @@
+package ts3
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCmdsMailbox(t *testing.T) {
+	s := newServer(t)
+	defer func() {
+		assert.NoError(t, s.Close())
+	}()
+
+	c, err := NewClient(s.Addr, Timeout(time.Second*2))
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	defer func() {
+		assert.NoError(t, c.Close())
+	}()
+
+	testCmdsMailbox(t, c, s)
+}
+
+func TestCmdsMailboxSSH(t *testing.T) {
+	s := newServer(t, useSSH())
+	defer func() {
+		assert.NoError(t, s.Close())
+	}()
+
+	c, err := NewClient(s.Addr, Timeout(time.Second*2), SSH(sshClientTestConfig))
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	defer func() {
+		assert.NoError(t, c.Close())
+	}()
+
+	testCmdsMailbox(t, c, s)
+}
+
+func testCmdsMailbox(t *testing.T, c *Client, s *server) {
+	t.Helper()
+	lastCmd := func() string {
+		received := s.Received()
+		return received[len(received)-1]
+	}
+
+	messagelist := func(t *testing.T) {
+		t.Helper()
+		msgs, err := c.Server.MessageList()
+		if !assert.NoError(t, err) {
+			return
+		}
+
+		expected := []*Message{
+			{ID: 1, SenderUID: "xyz=", Subject: "Hi", Created: time.Unix(1259147468, 0)},
+			{ID: 2, SenderUID: "abc=", Subject: "Re", Created: time.Unix(1259421233, 0), Read: true},
+		}
+		assert.Equal(t, expected, msgs)
+	}
+
+	messageadd := func(t *testing.T) {
+		t.Helper()
+		assert.NoError(t, c.Server.MessageAdd("xyz=", "Hi", "Hello there"))
+		assert.Equal(t, `messageadd cluid=xyz= subject=Hi message=Hello\sthere`, lastCmd())
+	}
+
+	messageget := func(t *testing.T) {
+		t.Helper()
+		msg, err := c.Server.MessageGet(1)
+		if !assert.NoError(t, err) {
+			return
+		}
+
+		expected := &Message{
+			ID:        1,
+			SenderUID: "xyz=",
+			Subject:   "Hi",
+			Message:   "Hello there",
+			Created:   time.Unix(1259147468, 0),
+		}
+		assert.Equal(t, expected, msg)
+		assert.Equal(t, "messageget msgid=1", lastCmd())
+	}
+
+	messagedel := func(t *testing.T) {
+		t.Helper()
+		assert.NoError(t, c.Server.MessageDel(1))
+		assert.Equal(t, "messagedel msgid=1", lastCmd())
+	}
+
+	messageupdateflag := func(t *testing.T) {
+		t.Helper()
+		assert.NoError(t, c.Server.MessageUpdateFlag(1, true))
+		assert.Equal(t, "messageupdateflag msgid=1 flag=1", lastCmd())
+	}
+
+	tests := []struct {
+		name string
+		f    func(t *testing.T)
+	}{
+		{"messagelist", messagelist},
+		{"messageadd", messageadd},
+		{"messageget", messageget},
+		{"messagedel", messagedel},
+		{"messageupdateflag", messageupdateflag},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, tc.f)
+	}
+}
@@ -1,6 +1,7 @@
 package ts3
 
 import (
+	"context"
 	"strings"
 )
 
@@ -36,6 +37,11 @@ const (
 type Notification struct {
 	Type string
 	Data map[string]string
+
+	// entries are the values of each entry of a notification which lists
+	// more than one, such as a move of several clients. Data contains the
+	// values of all entries, later entries overwriting earlier ones.
+	entries []map[string]string
 }
 
 // Notifications returns a read-only channel that outputs received notifications.
@@ -60,15 +66,25 @@ func (c *Client) Notifications() <-chan Notification {
 //
 // Subscriptions can be reset with `Unregister()` but will also
 // be reset when calling `logout`, `login`, `use`.
+//
+// If Reconnect is enabled active subscriptions are restored on reconnect.
 func (c *Client) Register(event NotifyCategory) error {
+	return c.RegisterContext(context.Background(), event)
+}
+
+// RegisterContext registers for a NotifyCategory.
+func (c *Client) RegisterContext(ctx context.Context, event NotifyCategory) error {
 	if event == ChannelEvents {
-		return c.RegisterChannel(0)
+		return c.RegisterChannelContext(ctx, 0)
 	}
 
-	_, err := c.ExecCmd(NewCmd("servernotifyregister").WithArgs(
-		NewArg("event", event),
-	))
-	return err
+	cmd := NewCmd("servernotifyregister").WithArgs(NewArg("event", event))
+	if _, err := c.ExecCmdContext(ctx, cmd); err != nil {
+		return err
+	}
+
+	c.state.addEvent(cmd)
+	return nil
 }
 
 // RegisterChannel registers for channel event notifications.
@@ -76,26 +92,70 @@ func (c *Client) Register(event NotifyCategory) error {
 // It's not possible to subscribe to multiple channels.
 // To receive events for all channels the id can be set to 0.
 func (c *Client) RegisterChannel(id uint) error {
-	_, err := c.ExecCmd(NewCmd("servernotifyregister").WithArgs(
+	return c.RegisterChannelContext(context.Background(), id)
+}
+
+// RegisterChannelContext registers for channel event notifications.
+func (c *Client) RegisterChannelContext(ctx context.Context, id uint) error {
+	cmd := NewCmd("servernotifyregister").WithArgs(
 		NewArg("event", ChannelEvents),
 		NewArg("id", id),
-	))
-	return err
+	)
+	if _, err := c.ExecCmdContext(ctx, cmd); err != nil {
+		return err
+	}
+
+	c.state.setChannel(cmd)
+	return nil
 }
 
 // Unregister unregisters all events previously registered.
 func (c *Client) Unregister() error {
-	_, err := c.Exec("servernotifyunregister")
-	return err
+	return c.UnregisterContext(context.Background())
+}
+
+// UnregisterContext unregisters all events previously registered.
+func (c *Client) UnregisterContext(ctx context.Context) error {
+	if _, err := c.ExecContext(ctx, "servernotifyunregister"); err != nil {
+		return err
+	}
+
+	c.state.setUnregister()
+	return nil
 }
 
+// decodeNotification decodes a notification line received from the server.
+// Values are kept as strings so that typed decoding can be done by Event.
 func decodeNotification(str string) (Notification, error) {
 	parts := strings.SplitN(str, " ", 2)
 	n := Notification{
 		Type: strings.TrimPrefix(parts[0], "notify"),
+		Data: make(map[string]string),
+	}
+
+	if len(parts) != 2 {
+		return n, NewInvalidResponseError("no data", []string{str})
 	}
 
-	err := DecodeResponse([]string{parts[1]}, &n.Data)
+	entries := strings.Split(parts[1], "|")
+	for _, part := range entries {
+		entry := make(map[string]string)
+		for _, val := range strings.Split(part, " ") {
+			kv := strings.SplitN(val, "=", 2)
+			if len(kv) == 2 {
+				entry[Decode(kv[0])] = Decode(kv[1])
+			} else {
+				entry[Decode(kv[0])] = ""
+			}
+		}
+
+		for k, v := range entry {
+			n.Data[k] = v
+		}
+		if len(entries) > 1 {
+			n.entries = append(n.entries, entry)
+		}
+	}
 
-	return n, err
+	return n, nil
 }
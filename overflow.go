@@ -0,0 +1,263 @@
+package ts3
+
+import (
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// DefaultNotifyBlockTimeout is the default maximum time OverflowBlock
+// waits for space in the notification buffer.
+var DefaultNotifyBlockTimeout = time.Second
+
+// OverflowPolicy determines what happens to a notification when the
+// notification buffer is full.
+type OverflowPolicy int
+
+const (
+	// OverflowDropNewest drops the notification being delivered. This is the default.
+	OverflowDropNewest OverflowPolicy = iota
+
+	// OverflowDropOldest drops the oldest buffered notification to make room.
+	OverflowDropOldest
+
+	// OverflowBlock waits up to the configured block timeout for room in the
+	// buffer before dropping the notification. While waiting no responses or
+	// other notifications are processed.
+	OverflowBlock
+
+	// OverflowUnbounded queues notifications without limit so none are dropped.
+	// Memory usage will grow if notifications aren't consumed.
+	OverflowUnbounded
+)
+
+func (p OverflowPolicy) String() string {
+	switch p {
+	case OverflowDropNewest:
+		return "drop-newest"
+	case OverflowDropOldest:
+		return "drop-oldest"
+	case OverflowBlock:
+		return "block"
+	case OverflowUnbounded:
+		return "unbounded"
+	default:
+		return fmt.Sprintf("OverflowPolicy(%d)", int(p))
+	}
+}
+
+// NotificationOverflow sets the policy used when the notification buffer is full.
+func NotificationOverflow(policy OverflowPolicy) func(*Client) error {
+	return func(c *Client) error {
+		if policy < OverflowDropNewest || policy > OverflowUnbounded {
+			return fmt.Errorf("notification overflow: invalid policy %v", policy)
+		}
+		c.overflow = policy
+		return nil
+	}
+}
+
+// NotificationBlockTimeout sets the maximum time OverflowBlock waits for
+// room in the notification buffer.
+func NotificationBlockTimeout(timeout time.Duration) func(*Client) error {
+	return func(c *Client) error {
+		c.notifyBlockTimeout = timeout
+		return nil
+	}
+}
+
+// NotificationDropHandler sets a function which is called with each
+// notification dropped due to the overflow policy.
+// It's called from the goroutine reading from the server so it must not block.
+func NotificationDropHandler(f func(Notification)) func(*Client) error {
+	return func(c *Client) error {
+		c.onDropped = f
+		return nil
+	}
+}
+
+// NotificationErrorHandler sets a function which is called with the raw
+// line and error for each notification which can't be decoded, either at
+// all or into its typed Event.
+// It's called from the goroutine reading from the server so it must not block.
+func NotificationErrorHandler(f func(line string, err error)) func(*Client) error {
+	return func(c *Client) error {
+		c.onUndecodable = f
+		return nil
+	}
+}
+
+// NotificationStats contains notification delivery counters.
+type NotificationStats struct {
+	// Received is the number of notifications received from the server.
+	Received uint64
+
+	// Dropped is the number of notifications dropped due to the overflow policy.
+	Dropped uint64
+
+	// Undecodable is the number of notifications which couldn't be decoded.
+	Undecodable uint64
+}
+
+// NotificationStats returns the notification delivery counters.
+func (c *Client) NotificationStats() NotificationStats {
+	return NotificationStats{
+		Received:    atomic.LoadUint64(&c.stats.Received),
+		Dropped:     atomic.LoadUint64(&c.stats.Dropped),
+		Undecodable: atomic.LoadUint64(&c.stats.Undecodable),
+	}
+}
+
+// notificationQueue is an unbounded queue of notifications used
+// by OverflowUnbounded.
+type notificationQueue struct {
+	mtx    sync.Mutex
+	cond   *sync.Cond
+	queue  []Notification
+	closed bool
+}
+
+// newNotificationQueue returns a new notificationQueue.
+func newNotificationQueue() *notificationQueue {
+	q := &notificationQueue{}
+	q.cond = sync.NewCond(&q.mtx)
+	return q
+}
+
+// push adds n to the queue.
+func (q *notificationQueue) push(n Notification) {
+	q.mtx.Lock()
+	defer q.mtx.Unlock()
+
+	q.queue = append(q.queue, n)
+	q.cond.Signal()
+}
+
+// pop removes and returns the first notification in the queue, waiting
+// for one if needed. It returns false once the queue is closed and empty.
+func (q *notificationQueue) pop() (Notification, bool) {
+	q.mtx.Lock()
+	defer q.mtx.Unlock()
+
+	for len(q.queue) == 0 {
+		if q.closed {
+			return Notification{}, false
+		}
+		q.cond.Wait()
+	}
+
+	n := q.queue[0]
+	q.queue[0] = Notification{}
+	q.queue = q.queue[1:]
+
+	return n, true
+}
+
+// close closes the queue.
+func (q *notificationQueue) close() {
+	q.mtx.Lock()
+	defer q.mtx.Unlock()
+
+	q.closed = true
+	q.cond.Broadcast()
+}
+
+// forwardNotifications sends queued notifications to c.notify until
+// the queue is closed, then closes c.notify.
+// Once the client is closing notifications which can't be sent immediately
+// are dropped, so a consumer which has stopped reading doesn't block it.
+func (c *Client) forwardNotifications() {
+	defer close(c.notify)
+
+	for {
+		n, ok := c.notifyQueue.pop()
+		if !ok {
+			return
+		}
+
+		select {
+		case c.notify <- n:
+		case <-c.closing:
+			select {
+			case c.notify <- n:
+			default:
+				c.dropNotification(n)
+			}
+		}
+	}
+}
+
+// deliverNotification sends n to c.notify according to the overflow policy.
+func (c *Client) deliverNotification(n Notification) {
+	atomic.AddUint64(&c.stats.Received, 1)
+
+	switch c.overflow {
+	case OverflowUnbounded:
+		c.notifyQueue.push(n)
+		return
+	case OverflowBlock:
+		t := time.NewTimer(c.notifyBlockTimeout)
+		defer t.Stop()
+
+		select {
+		case c.notify <- n:
+			return
+		case <-t.C:
+		}
+	case OverflowDropOldest:
+		select {
+		case c.notify <- n:
+			return
+		default:
+		}
+
+		// Make room by discarding the oldest, unless a consumer beat us to it.
+		select {
+		case old := <-c.notify:
+			c.dropNotification(old)
+		default:
+		}
+
+		select {
+		case c.notify <- n:
+			return
+		default:
+		}
+	default:
+		select {
+		case c.notify <- n:
+			return
+		default:
+		}
+	}
+
+	c.dropNotification(n)
+}
+
+// closeNotify ensures c.notify is closed once all notifications are delivered.
+func (c *Client) closeNotify() {
+	if c.overflow == OverflowUnbounded {
+		// forwardNotifications will close c.notify.
+		c.notifyQueue.close()
+		return
+	}
+
+	close(c.notify)
+}
+
+// dropNotification records that n was dropped.
+func (c *Client) dropNotification(n Notification) {
+	atomic.AddUint64(&c.stats.Dropped, 1)
+	if c.onDropped != nil {
+		c.onDropped(n)
+	}
+}
+
+// undecodableNotification records that line couldn't be decoded.
+func (c *Client) undecodableNotification(line string, err error) {
+	atomic.AddUint64(&c.stats.Undecodable, 1)
+	if c.onUndecodable != nil {
+		c.onUndecodable(line, err)
+	}
+}
@@ -0,0 +1,167 @@
+package ts3
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDeliverNotification(t *testing.T) {
+	n1 := Notification{Type: "one"}
+	n2 := Notification{Type: "two"}
+
+	tests := map[string]struct {
+		policy   OverflowPolicy
+		expected Notification
+		dropped  Notification
+	}{
+		"drop-newest": {OverflowDropNewest, n1, n2},
+		"drop-oldest": {OverflowDropOldest, n2, n1},
+		"block":       {OverflowBlock, n1, n2},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			var dropped []Notification
+			c := &Client{
+				notify:             make(chan Notification, 1),
+				overflow:           tc.policy,
+				notifyBlockTimeout: time.Millisecond * 10,
+				onDropped: func(n Notification) {
+					dropped = append(dropped, n)
+				},
+			}
+
+			c.deliverNotification(n1)
+			c.deliverNotification(n2)
+
+			assert.Equal(t, tc.expected, <-c.notify)
+			assert.Equal(t, []Notification{tc.dropped}, dropped)
+			assert.Equal(t, NotificationStats{Received: 2, Dropped: 1}, c.NotificationStats())
+		})
+	}
+}
+
+func TestDeliverNotificationBlock(t *testing.T) {
+	c := &Client{
+		notify:             make(chan Notification, 1),
+		overflow:           OverflowBlock,
+		notifyBlockTimeout: time.Second,
+	}
+
+	c.deliverNotification(Notification{Type: "one"})
+	go func() {
+		time.Sleep(time.Millisecond * 10)
+		<-c.notify
+	}()
+	c.deliverNotification(Notification{Type: "two"})
+
+	assert.Equal(t, "two", (<-c.notify).Type)
+	assert.Equal(t, NotificationStats{Received: 2}, c.NotificationStats())
+}
+
+func TestDeliverNotificationUnbounded(t *testing.T) {
+	c := &Client{
+		notify:      make(chan Notification, 1),
+		overflow:    OverflowUnbounded,
+		notifyQueue: newNotificationQueue(),
+	}
+	go c.forwardNotifications()
+
+	for i := 0; i < 100; i++ {
+		c.deliverNotification(Notification{Data: map[string]string{"i": string(rune('0' + i%10))}})
+	}
+	c.closeNotify()
+
+	var i int
+	for n := range c.notify {
+		assert.Equal(t, string(rune('0'+i%10)), n.Data["i"])
+		i++
+	}
+	assert.Equal(t, 100, i)
+	assert.Equal(t, NotificationStats{Received: 100}, c.NotificationStats())
+}
+
+func TestDeliverNotificationUnboundedClosing(t *testing.T) {
+	c := &Client{
+		notify:      make(chan Notification, 1),
+		overflow:    OverflowUnbounded,
+		notifyQueue: newNotificationQueue(),
+		closing:     make(chan struct{}),
+	}
+	close(c.closing)
+
+	for i := 0; i < 3; i++ {
+		c.deliverNotification(Notification{Type: "one"})
+	}
+	c.closeNotify()
+
+	// No one is reading so forwardNotifications must not block.
+	done := make(chan struct{})
+	go func() {
+		c.forwardNotifications()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("forwardNotifications blocked")
+	}
+
+	assert.Len(t, c.notify, 1)
+	assert.Equal(t, NotificationStats{Received: 3, Dropped: 2}, c.NotificationStats())
+}
+
+func TestDispatchNotificationUndecodable(t *testing.T) {
+	var invalid []string
+	c := &Client{
+		dispatcher: newDispatcher(1),
+		onUndecodable: func(line string, err error) {
+			invalid = append(invalid, line)
+		},
+	}
+	defer c.dispatcher.close()
+
+	// Counted even though there are no subscriptions.
+	c.dispatchNotification("notifyclientmoved ctid=x", Notification{
+		Type: EventClientMoved,
+		Data: map[string]string{"ctid": "x"},
+	})
+	assert.Equal(t, NotificationStats{Undecodable: 1}, c.NotificationStats())
+	assert.Equal(t, []string{"notifyclientmoved ctid=x"}, invalid)
+}
+
+func TestClientNotificationStats(t *testing.T) {
+	s := newServer(t)
+	defer func() {
+		assert.NoError(t, s.Close())
+	}()
+
+	var invalid []string
+	c, err := NewClient(s.Addr,
+		Timeout(time.Second),
+		NotificationBuffer(1),
+		NotificationErrorHandler(func(line string, err error) {
+			invalid = append(invalid, line)
+		}),
+	)
+	require.NoError(t, err)
+
+	defer func() {
+		assert.NoError(t, c.Close())
+	}()
+
+	_, err = c.Exec(cmdNotify)
+	require.NoError(t, err)
+
+	assert.Equal(t, NotificationStats{Received: 2, Dropped: 1, Undecodable: 1}, c.NotificationStats())
+	assert.Equal(t, []string{"notifyinvalid"}, invalid)
+}
+
+func TestNotificationOverflowInvalid(t *testing.T) {
+	_, err := NewClient("", NotificationOverflow(OverflowPolicy(-1)))
+	assert.Error(t, err)
+}
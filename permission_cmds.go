@@ -0,0 +1,605 @@
+package ts3
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"sync"
+)
+
+// Perm identifies a permission either by ID, using PermID, or by name,
+// using PermName.
+type Perm interface {
+	CmdArg
+	perm()
+}
+
+// PermID identifies a permission by its numeric ID.
+type PermID int
+
+// ArgString implements CmdArg.
+func (p PermID) ArgString() string {
+	return NewArg("permid", int(p)).ArgString()
+}
+
+func (p PermID) perm() {}
+
+// String returns the ID as a string.
+func (p PermID) String() string {
+	return strconv.Itoa(int(p))
+}
+
+// PermName identifies a permission by its name e.g. "i_client_move_power".
+type PermName string
+
+// ArgString implements CmdArg.
+func (p PermName) ArgString() string {
+	return NewArg("permsid", string(p)).ArgString()
+}
+
+func (p PermName) perm() {}
+
+// PermTarget is the type of entity a permission is assigned to.
+type PermTarget int
+
+const (
+	// PermTargetServerGroup is a permission assigned to a server group.
+	PermTargetServerGroup PermTarget = iota
+	// PermTargetClient is a permission assigned to a client.
+	PermTargetClient
+	// PermTargetChannel is a permission assigned to a channel.
+	PermTargetChannel
+	// PermTargetChannelGroup is a permission assigned to a channel group.
+	PermTargetChannelGroup
+	// PermTargetChannelClient is a permission assigned to a client in a channel.
+	PermTargetChannelClient
+)
+
+// Permission represents a permission known to the server.
+type Permission struct {
+	ID          int    `ms:"permid"`
+	Name        string `ms:"permname"`
+	Description string `ms:"permdesc"`
+}
+
+// PermissionValue represents the value of a permission.
+type PermissionValue struct {
+	ID      int    `ms:"permid"`
+	Name    string `ms:"permsid"` // Empty if it couldn't be resolved.
+	Value   int    `ms:"permvalue"`
+	Negated bool   `ms:"permnegated"`
+	Skip    bool   `ms:"permskip"`
+}
+
+// PermissionSetting is a permission value to be assigned.
+// Negated is only used by server groups and Skip only by server groups
+// and clients.
+type PermissionSetting struct {
+	Perm    Perm
+	Value   int
+	Negated bool
+	Skip    bool
+}
+
+// PermissionAssignment represents where a permission is assigned.
+// ID1 and ID2 depend on Type, e.g. for PermTargetChannelClient
+// ID1 is the channel ID and ID2 the client database ID.
+type PermissionAssignment struct {
+	Type   PermTarget `ms:"t"`
+	ID1    int        `ms:"id1"`
+	ID2    int        `ms:"id2"`
+	PermID int        `ms:"p"`
+}
+
+// PermissionOverview represents a permission value which applies to a client.
+type PermissionOverview struct {
+	PermissionAssignment `ms:",squash"`
+	Value                int  `ms:"v"`
+	Negated              bool `ms:"n"`
+	Skip                 bool `ms:"s"`
+}
+
+// permCache is a cache of permission names and IDs.
+type permCache struct {
+	mtx    sync.Mutex
+	byID   map[int]string
+	byName map[string]int
+
+	// namesFailed is set if loading the cache failed when setting names
+	// so it isn't retried for every list until the cache is reset.
+	namesFailed bool
+}
+
+// PermissionList returns all permissions known to the server.
+func (s *ServerMethods) PermissionList() ([]*Permission, error) {
+	return s.PermissionListContext(context.Background())
+}
+
+// PermissionListContext returns all permissions known to the server.
+func (s *ServerMethods) PermissionListContext(ctx context.Context) ([]*Permission, error) {
+	var entries []*Permission
+	if _, err := s.ExecCmdContext(ctx, NewCmd("permissionlist").WithResponse(&entries)); err != nil {
+		return nil, err
+	}
+
+	// Newer servers include group markers which aren't permissions.
+	perms := entries[:0]
+	for _, p := range entries {
+		if p.Name != "" {
+			perms = append(perms, p)
+		}
+	}
+
+	return perms, nil
+}
+
+// ResolvePermID returns the ID of perm.
+// Names are resolved using a cache loaded from PermissionList on first use.
+func (s *ServerMethods) ResolvePermID(perm Perm) (int, error) {
+	return s.ResolvePermIDContext(context.Background(), perm)
+}
+
+// ResolvePermIDContext returns the ID of perm.
+func (s *ServerMethods) ResolvePermIDContext(ctx context.Context, perm Perm) (int, error) {
+	var name PermName
+	switch p := perm.(type) {
+	case PermID:
+		return int(p), nil
+	case PermName:
+		name = p
+	default:
+		return 0, fmt.Errorf("resolve permission: %w", ErrNilPerm)
+	}
+
+	if err := s.loadPerms(ctx); err != nil {
+		return 0, err
+	}
+
+	s.perms.mtx.Lock()
+	defer s.perms.mtx.Unlock()
+
+	id, ok := s.perms.byName[string(name)]
+	if !ok {
+		return 0, fmt.Errorf("resolve permission: unknown permission %q", perm)
+	}
+
+	return id, nil
+}
+
+// ResolvePermName returns the name of perm.
+// IDs are resolved using a cache loaded from PermissionList on first use.
+func (s *ServerMethods) ResolvePermName(perm Perm) (string, error) {
+	return s.ResolvePermNameContext(context.Background(), perm)
+}
+
+// ResolvePermNameContext returns the name of perm.
+func (s *ServerMethods) ResolvePermNameContext(ctx context.Context, perm Perm) (string, error) {
+	var id PermID
+	switch p := perm.(type) {
+	case PermName:
+		return string(p), nil
+	case PermID:
+		id = p
+	default:
+		return "", fmt.Errorf("resolve permission: %w", ErrNilPerm)
+	}
+
+	if err := s.loadPerms(ctx); err != nil {
+		return "", err
+	}
+
+	s.perms.mtx.Lock()
+	defer s.perms.mtx.Unlock()
+
+	name, ok := s.perms.byID[int(id)]
+	if !ok {
+		return "", fmt.Errorf("resolve permission: unknown permission %v", perm)
+	}
+
+	return name, nil
+}
+
+// ResetPermCache clears the cache used to resolve permissions so it's
+// reloaded on next use, including after a failure to load it.
+func (s *ServerMethods) ResetPermCache() {
+	s.perms.mtx.Lock()
+	defer s.perms.mtx.Unlock()
+
+	s.perms.byID = nil
+	s.perms.byName = nil
+	s.perms.namesFailed = false
+}
+
+// loadPerms loads the permission cache if needed.
+func (s *ServerMethods) loadPerms(ctx context.Context) error {
+	s.perms.mtx.Lock()
+	loaded := s.perms.byID != nil
+	s.perms.mtx.Unlock()
+
+	if loaded {
+		return nil
+	}
+
+	perms, err := s.PermissionListContext(ctx)
+	if err != nil {
+		return err
+	}
+
+	byID := make(map[int]string, len(perms))
+	byName := make(map[string]int, len(perms))
+	for _, p := range perms {
+		byID[p.ID] = p.Name
+		byName[p.Name] = p.ID
+	}
+
+	s.perms.mtx.Lock()
+	defer s.perms.mtx.Unlock()
+
+	s.perms.byID = byID
+	s.perms.byName = byName
+
+	return nil
+}
+
+// setPermNames sets the Name of each of values from the permission cache.
+// It's best effort, if the cache can't be loaded, for example because
+// PermissionList isn't permitted, the names are left empty and loading
+// isn't attempted again until ResetPermCache is called.
+func (s *ServerMethods) setPermNames(ctx context.Context, values []*PermissionValue) {
+	s.perms.mtx.Lock()
+	failed := s.perms.namesFailed
+	s.perms.mtx.Unlock()
+
+	if failed {
+		return
+	}
+
+	err := s.loadPerms(ctx)
+
+	s.perms.mtx.Lock()
+	defer s.perms.mtx.Unlock()
+
+	if err != nil {
+		s.perms.namesFailed = true
+		return
+	}
+
+	for _, v := range values {
+		if v.Name == "" {
+			v.Name = s.perms.byID[v.ID]
+		}
+	}
+}
+
+// PermIDGetByName returns the IDs of the permissions with the given names.
+func (s *ServerMethods) PermIDGetByName(names ...string) (map[string]int, error) {
+	return s.PermIDGetByNameContext(context.Background(), names...)
+}
+
+// PermIDGetByNameContext returns the IDs of the permissions with the given names.
+func (s *ServerMethods) PermIDGetByNameContext(ctx context.Context, names ...string) (map[string]int, error) {
+	args := make([]CmdArg, len(names))
+	for i, name := range names {
+		args[i] = PermName(name)
+	}
+
+	var perms []*PermissionValue
+	if _, err := s.ExecCmdContext(ctx, NewCmd("permidgetbyname").WithArgs(NewArgGroup(args...)).WithResponse(&perms)); err != nil {
+		return nil, err
+	}
+
+	ids := make(map[string]int, len(perms))
+	for _, p := range perms {
+		ids[p.Name] = p.ID
+	}
+
+	return ids, nil
+}
+
+// PermFind returns where perm is assigned.
+func (s *ServerMethods) PermFind(perm Perm) ([]*PermissionAssignment, error) {
+	return s.PermFindContext(context.Background(), perm)
+}
+
+// PermFindContext returns where perm is assigned.
+func (s *ServerMethods) PermFindContext(ctx context.Context, perm Perm) ([]*PermissionAssignment, error) {
+	if perm == nil {
+		return nil, fmt.Errorf("perm find: %w", ErrNilPerm)
+	}
+
+	var assignments []*PermissionAssignment
+	if _, err := s.ExecCmdContext(ctx, NewCmd("permfind").WithArgs(perm).WithResponse(&assignments)); err != nil {
+		return nil, err
+	}
+
+	return assignments, nil
+}
+
+// PermGet returns the values of perms for the current ServerQuery client.
+func (s *ServerMethods) PermGet(perms ...Perm) ([]*PermissionValue, error) {
+	return s.PermGetContext(context.Background(), perms...)
+}
+
+// PermGetContext returns the values of perms for the current ServerQuery client.
+func (s *ServerMethods) PermGetContext(ctx context.Context, perms ...Perm) ([]*PermissionValue, error) {
+	group, err := permGroup(perms)
+	if err != nil {
+		return nil, fmt.Errorf("perm get: %w", err)
+	}
+
+	var values []*PermissionValue
+	if _, err := s.ExecCmdContext(ctx, NewCmd("permget").WithArgs(group).WithResponse(&values)); err != nil {
+		return nil, err
+	}
+
+	return values, nil
+}
+
+// PermOverview returns the values of perm which apply to the client with
+// database ID dbID in channel id. Passing PermID(0) returns all permissions.
+func (s *ServerMethods) PermOverview(id, dbID int, perm Perm) ([]*PermissionOverview, error) {
+	return s.PermOverviewContext(context.Background(), id, dbID, perm)
+}
+
+// PermOverviewContext returns the values of perm which apply to the client with
+// database ID dbID in channel id.
+func (s *ServerMethods) PermOverviewContext(ctx context.Context, id, dbID int, perm Perm) ([]*PermissionOverview, error) {
+	if perm == nil {
+		return nil, fmt.Errorf("perm overview: %w", ErrNilPerm)
+	}
+
+	var overview []*PermissionOverview
+	if _, err := s.ExecCmdContext(ctx, NewCmd("permoverview").WithArgs(
+		NewArg("cid", id),
+		NewArg("cldbid", dbID),
+		perm,
+	).WithResponse(&overview)); err != nil {
+		return nil, err
+	}
+
+	return overview, nil
+}
+
+// PermReset resets all permissions on the selected server to their defaults
+// and returns the new server admin privilege key.
+func (s *ServerMethods) PermReset() (string, error) {
+	return s.PermResetContext(context.Background())
+}
+
+// PermResetContext resets all permissions on the selected server to their defaults
+// and returns the new server admin privilege key.
+func (s *ServerMethods) PermResetContext(ctx context.Context) (string, error) {
+	r := struct {
+		Token string `ms:"token"`
+	}{}
+	if _, err := s.ExecCmdContext(ctx, NewCmd("permreset").WithResponse(&r)); err != nil {
+		return "", err
+	}
+
+	return r.Token, nil
+}
+
+// permGroup returns an ArgGroup for perms.
+// It returns ErrNilPerm if any of perms is nil.
+func permGroup(perms []Perm) (*ArgGroup, error) {
+	args := make([]CmdArg, len(perms))
+	for i, p := range perms {
+		if p == nil {
+			return nil, ErrNilPerm
+		}
+		args[i] = p
+	}
+	return NewArgGroup(args...), nil
+}
+
+// settingGroup returns an ArgGroup for perms including the negated
+// and skip flags if requested.
+// It returns ErrNilPerm if the Perm of any of perms is nil.
+func settingGroup(perms []PermissionSetting, negated, skip bool) (*ArgGroup, error) {
+	args := make([]CmdArg, len(perms))
+	for i, p := range perms {
+		if p.Perm == nil {
+			return nil, ErrNilPerm
+		}
+		set := []CmdArg{p.Perm, NewArg("permvalue", p.Value)}
+		if negated {
+			set = append(set, NewArg("permnegated", p.Negated))
+		}
+		if skip {
+			set = append(set, NewArg("permskip", p.Skip))
+		}
+		args[i] = NewArgSet(set...)
+	}
+	return NewArgGroup(args...), nil
+}
+
+// addPerms runs the add permission command cmd for target including the
+// negated and skip flags if requested.
+func (s *ServerMethods) addPerms(ctx context.Context, cmd string, target []CmdArg, perms []PermissionSetting, negated, skip bool) error {
+	group, err := settingGroup(perms, negated, skip)
+	if err != nil {
+		return fmt.Errorf("%s: %w", cmd, err)
+	}
+
+	_, err = s.ExecCmdContext(ctx, NewCmd(cmd).WithArgs(append(target, group)...))
+	return err
+}
+
+// delPerms runs the delete permission command cmd for target.
+func (s *ServerMethods) delPerms(ctx context.Context, cmd string, target []CmdArg, perms []Perm) error {
+	group, err := permGroup(perms)
+	if err != nil {
+		return fmt.Errorf("%s: %w", cmd, err)
+	}
+
+	_, err = s.ExecCmdContext(ctx, NewCmd(cmd).WithArgs(append(target, group)...))
+	return err
+}
+
+// permList runs the permission list command cmd for target.
+func (s *ServerMethods) permList(ctx context.Context, cmd string, target ...CmdArg) ([]*PermissionValue, error) {
+	var values []*PermissionValue
+	if _, err := s.ExecCmdContext(ctx, NewCmd(cmd).WithArgs(target...).WithResponse(&values)); err != nil {
+		return nil, err
+	}
+
+	s.setPermNames(ctx, values)
+
+	return values, nil
+}
+
+// ServerGroupAddPerm adds or updates perms for the server group id.
+func (s *ServerMethods) ServerGroupAddPerm(id int, perms ...PermissionSetting) error {
+	return s.ServerGroupAddPermContext(context.Background(), id, perms...)
+}
+
+// ServerGroupAddPermContext adds or updates perms for the server group id.
+func (s *ServerMethods) ServerGroupAddPermContext(ctx context.Context, id int, perms ...PermissionSetting) error {
+	return s.addPerms(ctx, "servergroupaddperm", []CmdArg{NewArg("sgid", id)}, perms, true, true)
+}
+
+// ServerGroupDelPerm removes perms from the server group id.
+func (s *ServerMethods) ServerGroupDelPerm(id int, perms ...Perm) error {
+	return s.ServerGroupDelPermContext(context.Background(), id, perms...)
+}
+
+// ServerGroupDelPermContext removes perms from the server group id.
+func (s *ServerMethods) ServerGroupDelPermContext(ctx context.Context, id int, perms ...Perm) error {
+	return s.delPerms(ctx, "servergroupdelperm", []CmdArg{NewArg("sgid", id)}, perms)
+}
+
+// ServerGroupPermList returns the permissions assigned to the server group id.
+func (s *ServerMethods) ServerGroupPermList(id int) ([]*PermissionValue, error) {
+	return s.ServerGroupPermListContext(context.Background(), id)
+}
+
+// ServerGroupPermListContext returns the permissions assigned to the server group id.
+func (s *ServerMethods) ServerGroupPermListContext(ctx context.Context, id int) ([]*PermissionValue, error) {
+	return s.permList(ctx, "servergrouppermlist", NewArg("sgid", id))
+}
+
+// ChannelGroupAddPerm adds or updates perms for the channel group id.
+func (s *ServerMethods) ChannelGroupAddPerm(id int, perms ...PermissionSetting) error {
+	return s.ChannelGroupAddPermContext(context.Background(), id, perms...)
+}
+
+// ChannelGroupAddPermContext adds or updates perms for the channel group id.
+func (s *ServerMethods) ChannelGroupAddPermContext(ctx context.Context, id int, perms ...PermissionSetting) error {
+	return s.addPerms(ctx, "channelgroupaddperm", []CmdArg{NewArg("cgid", id)}, perms, false, false)
+}
+
+// ChannelGroupDelPerm removes perms from the channel group id.
+func (s *ServerMethods) ChannelGroupDelPerm(id int, perms ...Perm) error {
+	return s.ChannelGroupDelPermContext(context.Background(), id, perms...)
+}
+
+// ChannelGroupDelPermContext removes perms from the channel group id.
+func (s *ServerMethods) ChannelGroupDelPermContext(ctx context.Context, id int, perms ...Perm) error {
+	return s.delPerms(ctx, "channelgroupdelperm", []CmdArg{NewArg("cgid", id)}, perms)
+}
+
+// ChannelGroupPermList returns the permissions assigned to the channel group id.
+func (s *ServerMethods) ChannelGroupPermList(id int) ([]*PermissionValue, error) {
+	return s.ChannelGroupPermListContext(context.Background(), id)
+}
+
+// ChannelGroupPermListContext returns the permissions assigned to the channel group id.
+func (s *ServerMethods) ChannelGroupPermListContext(ctx context.Context, id int) ([]*PermissionValue, error) {
+	return s.permList(ctx, "channelgrouppermlist", NewArg("cgid", id))
+}
+
+// ChannelAddPerm adds or updates perms for the channel id.
+func (s *ServerMethods) ChannelAddPerm(id int, perms ...PermissionSetting) error {
+	return s.ChannelAddPermContext(context.Background(), id, perms...)
+}
+
+// ChannelAddPermContext adds or updates perms for the channel id.
+func (s *ServerMethods) ChannelAddPermContext(ctx context.Context, id int, perms ...PermissionSetting) error {
+	return s.addPerms(ctx, "channeladdperm", []CmdArg{NewArg("cid", id)}, perms, false, false)
+}
+
+// ChannelDelPerm removes perms from the channel id.
+func (s *ServerMethods) ChannelDelPerm(id int, perms ...Perm) error {
+	return s.ChannelDelPermContext(context.Background(), id, perms...)
+}
+
+// ChannelDelPermContext removes perms from the channel id.
+func (s *ServerMethods) ChannelDelPermContext(ctx context.Context, id int, perms ...Perm) error {
+	return s.delPerms(ctx, "channeldelperm", []CmdArg{NewArg("cid", id)}, perms)
+}
+
+// ChannelPermList returns the permissions assigned to the channel id.
+func (s *ServerMethods) ChannelPermList(id int) ([]*PermissionValue, error) {
+	return s.ChannelPermListContext(context.Background(), id)
+}
+
+// ChannelPermListContext returns the permissions assigned to the channel id.
+func (s *ServerMethods) ChannelPermListContext(ctx context.Context, id int) ([]*PermissionValue, error) {
+	return s.permList(ctx, "channelpermlist", NewArg("cid", id))
+}
+
+// ClientAddPerm adds or updates perms for the client with database ID dbID.
+func (s *ServerMethods) ClientAddPerm(dbID int, perms ...PermissionSetting) error {
+	return s.ClientAddPermContext(context.Background(), dbID, perms...)
+}
+
+// ClientAddPermContext adds or updates perms for the client with database ID dbID.
+func (s *ServerMethods) ClientAddPermContext(ctx context.Context, dbID int, perms ...PermissionSetting) error {
+	return s.addPerms(ctx, "clientaddperm", []CmdArg{NewArg("cldbid", dbID)}, perms, false, true)
+}
+
+// ClientDelPerm removes perms from the client with database ID dbID.
+func (s *ServerMethods) ClientDelPerm(dbID int, perms ...Perm) error {
+	return s.ClientDelPermContext(context.Background(), dbID, perms...)
+}
+
+// ClientDelPermContext removes perms from the client with database ID dbID.
+func (s *ServerMethods) ClientDelPermContext(ctx context.Context, dbID int, perms ...Perm) error {
+	return s.delPerms(ctx, "clientdelperm", []CmdArg{NewArg("cldbid", dbID)}, perms)
+}
+
+// ClientPermList returns the permissions assigned to the client with database ID dbID.
+func (s *ServerMethods) ClientPermList(dbID int) ([]*PermissionValue, error) {
+	return s.ClientPermListContext(context.Background(), dbID)
+}
+
+// ClientPermListContext returns the permissions assigned to the client with database ID dbID.
+func (s *ServerMethods) ClientPermListContext(ctx context.Context, dbID int) ([]*PermissionValue, error) {
+	return s.permList(ctx, "clientpermlist", NewArg("cldbid", dbID))
+}
+
+// ChannelClientAddPerm adds or updates perms for the client with database ID
+// dbID in channel id.
+func (s *ServerMethods) ChannelClientAddPerm(id, dbID int, perms ...PermissionSetting) error {
+	return s.ChannelClientAddPermContext(context.Background(), id, dbID, perms...)
+}
+
+// ChannelClientAddPermContext adds or updates perms for the client with database ID
+// dbID in channel id.
+func (s *ServerMethods) ChannelClientAddPermContext(ctx context.Context, id, dbID int, perms ...PermissionSetting) error {
+	return s.addPerms(ctx, "channelclientaddperm", []CmdArg{NewArg("cid", id), NewArg("cldbid", dbID)}, perms, false, false)
+}
+
+// ChannelClientDelPerm removes perms from the client with database ID dbID
+// in channel id.
+func (s *ServerMethods) ChannelClientDelPerm(id, dbID int, perms ...Perm) error {
+	return s.ChannelClientDelPermContext(context.Background(), id, dbID, perms...)
+}
+
+// ChannelClientDelPermContext removes perms from the client with database ID dbID
+// in channel id.
+func (s *ServerMethods) ChannelClientDelPermContext(ctx context.Context, id, dbID int, perms ...Perm) error {
+	return s.delPerms(ctx, "channelclientdelperm", []CmdArg{NewArg("cid", id), NewArg("cldbid", dbID)}, perms)
+}
+
+// ChannelClientPermList returns the permissions assigned to the client with
+// database ID dbID in channel id.
+func (s *ServerMethods) ChannelClientPermList(id, dbID int) ([]*PermissionValue, error) {
+	return s.ChannelClientPermListContext(context.Background(), id, dbID)
+}
+
+// ChannelClientPermListContext returns the permissions assigned to the client with
+// database ID dbID in channel id.
+func (s *ServerMethods) ChannelClientPermListContext(ctx context.Context, id, dbID int) ([]*PermissionValue, error) {
+	return s.permList(ctx, "channelclientpermlist", NewArg("cid", id), NewArg("cldbid", dbID))
+}
@@ -0,0 +1,340 @@
+package ts3
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCmdsPermission(t *testing.T) {
+	s := newServer(t)
+	defer func() {
+		assert.NoError(t, s.Close())
+	}()
+
+	c, err := NewClient(s.Addr, Timeout(time.Second*2))
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	defer func() {
+		assert.NoError(t, c.Close())
+	}()
+
+	testCmdsPermission(t, c, s)
+}
+
+func TestCmdsPermissionSSH(t *testing.T) {
+	s := newServer(t, useSSH())
+	defer func() {
+		assert.NoError(t, s.Close())
+	}()
+
+	c, err := NewClient(s.Addr, Timeout(time.Second*2), SSH(sshClientTestConfig))
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	defer func() {
+		assert.NoError(t, c.Close())
+	}()
+
+	testCmdsPermission(t, c, s)
+}
+
+func testCmdsPermission(t *testing.T, c *Client, s *server) {
+	t.Helper()
+	lastCmd := func() string {
+		received := s.Received()
+		return received[len(received)-1]
+	}
+
+	countCmd := func(cmd string) int {
+		var n int
+		for _, r := range s.Received() {
+			if r == cmd {
+				n++
+			}
+		}
+		return n
+	}
+
+	expectedValues := []*PermissionValue{
+		{ID: 1, Name: "b_serverinstance_help_view", Value: 1},
+		{ID: 2, Name: "i_client_move_power", Value: 75, Negated: true, Skip: true},
+	}
+
+	permissionlist := func(t *testing.T) {
+		t.Helper()
+		perms, err := c.Server.PermissionList()
+		if !assert.NoError(t, err) {
+			return
+		}
+
+		expected := []*Permission{
+			{ID: 1, Name: "b_serverinstance_help_view", Description: "Retrieve information"},
+			{ID: 2, Name: "i_client_move_power", Description: "Move power"},
+		}
+		assert.Equal(t, expected, perms)
+	}
+
+	resolve := func(t *testing.T) {
+		t.Helper()
+		c.Server.ResetPermCache()
+		before := countCmd("permissionlist")
+
+		id, err := c.Server.ResolvePermID(PermName("i_client_move_power"))
+		require.NoError(t, err)
+		assert.Equal(t, 2, id)
+
+		id, err = c.Server.ResolvePermID(PermID(5))
+		require.NoError(t, err)
+		assert.Equal(t, 5, id)
+
+		name, err := c.Server.ResolvePermName(PermID(1))
+		require.NoError(t, err)
+		assert.Equal(t, "b_serverinstance_help_view", name)
+
+		name, err = c.Server.ResolvePermName(PermName("b_foo"))
+		require.NoError(t, err)
+		assert.Equal(t, "b_foo", name)
+
+		_, err = c.Server.ResolvePermID(PermName("b_unknown"))
+		assert.Error(t, err)
+
+		_, err = c.Server.ResolvePermName(PermID(99))
+		assert.Error(t, err)
+
+		_, err = c.Server.ResolvePermID(nil)
+		assert.Error(t, err)
+
+		_, err = c.Server.ResolvePermName(nil)
+		assert.Error(t, err)
+
+		assert.Equal(t, before+1, countCmd("permissionlist"))
+	}
+
+	permidgetbyname := func(t *testing.T) {
+		t.Helper()
+		ids, err := c.Server.PermIDGetByName("b_serverinstance_help_view", "i_client_move_power")
+		if !assert.NoError(t, err) {
+			return
+		}
+
+		assert.Equal(t, map[string]int{"b_serverinstance_help_view": 1, "i_client_move_power": 2}, ids)
+		assert.Equal(t, "permidgetbyname permsid=b_serverinstance_help_view|permsid=i_client_move_power", lastCmd())
+	}
+
+	permfind := func(t *testing.T) {
+		t.Helper()
+		found, err := c.Server.PermFind(PermName("i_client_move_power"))
+		if !assert.NoError(t, err) {
+			return
+		}
+
+		expected := []*PermissionAssignment{
+			{Type: PermTargetServerGroup, ID1: 2, PermID: 2},
+			{Type: PermTargetChannelClient, ID1: 5, ID2: 7, PermID: 2},
+		}
+		assert.Equal(t, expected, found)
+		assert.Equal(t, "permfind permsid=i_client_move_power", lastCmd())
+	}
+
+	permget := func(t *testing.T) {
+		t.Helper()
+		values, err := c.Server.PermGet(PermID(2))
+		if !assert.NoError(t, err) {
+			return
+		}
+
+		expected := []*PermissionValue{{ID: 2, Name: "i_client_move_power", Value: 100}}
+		assert.Equal(t, expected, values)
+		assert.Equal(t, "permget permid=2", lastCmd())
+	}
+
+	permoverview := func(t *testing.T) {
+		t.Helper()
+		overview, err := c.Server.PermOverview(5, 7, PermID(0))
+		if !assert.NoError(t, err) {
+			return
+		}
+
+		expected := []*PermissionOverview{
+			{
+				PermissionAssignment: PermissionAssignment{Type: PermTargetServerGroup, ID1: 2, PermID: 2},
+				Value:                75,
+				Skip:                 true,
+			},
+		}
+		assert.Equal(t, expected, overview)
+		assert.Equal(t, "permoverview cid=5 cldbid=7 permid=0", lastCmd())
+	}
+
+	permreset := func(t *testing.T) {
+		t.Helper()
+		token, err := c.Server.PermReset()
+		if !assert.NoError(t, err) {
+			return
+		}
+		assert.Equal(t, "eKnFZQ9EK7G7MhtuQB6+N2B1PNZZ6OZL3ycDp2OW", token)
+	}
+
+	settings := []PermissionSetting{
+		{Perm: PermName("i_client_move_power"), Value: 75, Negated: true, Skip: true},
+		{Perm: PermID(1), Value: 1},
+	}
+	perms := []Perm{PermName("i_client_move_power"), PermID(1)}
+
+	servergroup := func(t *testing.T) {
+		t.Helper()
+		assert.NoError(t, c.Server.ServerGroupAddPerm(2, settings...))
+		assert.Equal(t, "servergroupaddperm sgid=2 permsid=i_client_move_power permvalue=75 permnegated=1 permskip=1|permid=1 permvalue=1 permnegated=0 permskip=0", lastCmd())
+
+		assert.NoError(t, c.Server.ServerGroupDelPerm(2, perms...))
+		assert.Equal(t, "servergroupdelperm sgid=2 permsid=i_client_move_power|permid=1", lastCmd())
+
+		values, err := c.Server.ServerGroupPermList(2)
+		if assert.NoError(t, err) {
+			assert.Equal(t, expectedValues, values)
+		}
+	}
+
+	channelgroup := func(t *testing.T) {
+		t.Helper()
+		assert.NoError(t, c.Server.ChannelGroupAddPerm(5, settings...))
+		assert.Equal(t, "channelgroupaddperm cgid=5 permsid=i_client_move_power permvalue=75|permid=1 permvalue=1", lastCmd())
+
+		assert.NoError(t, c.Server.ChannelGroupDelPerm(5, perms...))
+		assert.Equal(t, "channelgroupdelperm cgid=5 permsid=i_client_move_power|permid=1", lastCmd())
+
+		values, err := c.Server.ChannelGroupPermList(5)
+		if assert.NoError(t, err) {
+			assert.Equal(t, expectedValues, values)
+		}
+	}
+
+	channel := func(t *testing.T) {
+		t.Helper()
+		assert.NoError(t, c.Server.ChannelAddPerm(499, settings...))
+		assert.Equal(t, "channeladdperm cid=499 permsid=i_client_move_power permvalue=75|permid=1 permvalue=1", lastCmd())
+
+		assert.NoError(t, c.Server.ChannelDelPerm(499, perms...))
+		assert.Equal(t, "channeldelperm cid=499 permsid=i_client_move_power|permid=1", lastCmd())
+
+		values, err := c.Server.ChannelPermList(499)
+		if assert.NoError(t, err) {
+			assert.Equal(t, expectedValues, values)
+		}
+	}
+
+	client := func(t *testing.T) {
+		t.Helper()
+		assert.NoError(t, c.Server.ClientAddPerm(7, settings...))
+		assert.Equal(t, "clientaddperm cldbid=7 permsid=i_client_move_power permvalue=75 permskip=1|permid=1 permvalue=1 permskip=0", lastCmd())
+
+		assert.NoError(t, c.Server.ClientDelPerm(7, perms...))
+		assert.Equal(t, "clientdelperm cldbid=7 permsid=i_client_move_power|permid=1", lastCmd())
+
+		values, err := c.Server.ClientPermList(7)
+		if assert.NoError(t, err) {
+			assert.Equal(t, expectedValues, values)
+		}
+	}
+
+	channelclient := func(t *testing.T) {
+		t.Helper()
+		assert.NoError(t, c.Server.ChannelClientAddPerm(499, 7, settings...))
+		assert.Equal(t, "channelclientaddperm cid=499 cldbid=7 permsid=i_client_move_power permvalue=75|permid=1 permvalue=1", lastCmd())
+
+		assert.NoError(t, c.Server.ChannelClientDelPerm(499, 7, perms...))
+		assert.Equal(t, "channelclientdelperm cid=499 cldbid=7 permsid=i_client_move_power|permid=1", lastCmd())
+
+		values, err := c.Server.ChannelClientPermList(499, 7)
+		if assert.NoError(t, err) {
+			assert.Equal(t, expectedValues, values)
+		}
+	}
+
+	unresolved := func(t *testing.T) {
+		t.Helper()
+		s.setResponse("permissionlist", `error id=2568 msg=insufficient\sclient\spermissions failed_permid=4`)
+		defer s.setResponse("permissionlist", commands["permissionlist"])
+		defer c.Server.ResetPermCache()
+		c.Server.ResetPermCache()
+		before := countCmd("permissionlist")
+
+		// Names are best effort so the values are still returned.
+		values, err := c.Server.ServerGroupPermList(2)
+		require.NoError(t, err)
+		if assert.Len(t, values, 2) {
+			assert.Equal(t, 2, values[1].ID)
+			assert.Empty(t, values[1].Name)
+		}
+
+		// The failure is cached until the cache is reset.
+		_, err = c.Server.ChannelPermList(499)
+		require.NoError(t, err)
+		assert.Equal(t, before+1, countCmd("permissionlist"))
+
+		c.Server.ResetPermCache()
+		_, err = c.Server.ChannelPermList(499)
+		require.NoError(t, err)
+		assert.Equal(t, before+2, countCmd("permissionlist"))
+	}
+
+	nilPerm := func(t *testing.T) {
+		t.Helper()
+		before := len(s.Received())
+		nilSettings := []PermissionSetting{{Perm: PermID(1), Value: 1}, {Value: 1}}
+		nilPerms := []Perm{PermID(1), nil}
+
+		_, err := c.Server.PermFind(nil)
+		assert.True(t, errors.Is(err, ErrNilPerm))
+
+		_, err = c.Server.PermGet(nilPerms...)
+		assert.True(t, errors.Is(err, ErrNilPerm))
+
+		_, err = c.Server.PermOverview(5, 7, nil)
+		assert.True(t, errors.Is(err, ErrNilPerm))
+
+		assert.True(t, errors.Is(c.Server.ServerGroupAddPerm(2, nilSettings...), ErrNilPerm))
+		assert.True(t, errors.Is(c.Server.ServerGroupDelPerm(2, nilPerms...), ErrNilPerm))
+		assert.True(t, errors.Is(c.Server.ChannelGroupAddPerm(5, nilSettings...), ErrNilPerm))
+		assert.True(t, errors.Is(c.Server.ChannelGroupDelPerm(5, nilPerms...), ErrNilPerm))
+		assert.True(t, errors.Is(c.Server.ChannelAddPerm(499, nilSettings...), ErrNilPerm))
+		assert.True(t, errors.Is(c.Server.ChannelDelPerm(499, nilPerms...), ErrNilPerm))
+		assert.True(t, errors.Is(c.Server.ClientAddPerm(7, nilSettings...), ErrNilPerm))
+		assert.True(t, errors.Is(c.Server.ClientDelPerm(7, nilPerms...), ErrNilPerm))
+		assert.True(t, errors.Is(c.Server.ChannelClientAddPerm(499, 7, nilSettings...), ErrNilPerm))
+		assert.True(t, errors.Is(c.Server.ChannelClientDelPerm(499, 7, nilPerms...), ErrNilPerm))
+
+		assert.Len(t, s.Received(), before)
+	}
+
+	tests := []struct {
+		name string
+		f    func(t *testing.T)
+	}{
+		{"permissionlist", permissionlist},
+		{"resolve", resolve},
+		{"permidgetbyname", permidgetbyname},
+		{"permfind", permfind},
+		{"permget", permget},
+		{"permoverview", permoverview},
+		{"permreset", permreset},
+		{"servergroup", servergroup},
+		{"channelgroup", channelgroup},
+		{"channel", channel},
+		{"client", client},
+		{"channelclient", channelclient},
+		{"unresolved", unresolved},
+		{"nil", nilPerm},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, tc.f)
+	}
+}
@@ -0,0 +1,292 @@
+package ts3
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+var (
+	// DefaultReconnectBackoff is the default delay between reconnect attempts.
+	DefaultReconnectBackoff = time.Second
+
+	// DefaultMaxReconnectBackoff is the default maximum delay between reconnect attempts.
+	DefaultMaxReconnectBackoff = time.Minute
+)
+
+// ReconnectPolicy configures how a Client reconnects after losing its
+// connection to the server.
+type ReconnectPolicy struct {
+	// MaxAttempts is the maximum number of consecutive reconnect attempts
+	// before giving up. Zero means no limit.
+	MaxAttempts int
+
+	// Backoff is the delay before the second attempt, which doubles
+	// for each subsequent attempt up to MaxBackoff.
+	// The first attempt is made immediately.
+	// Defaults to DefaultReconnectBackoff.
+	Backoff time.Duration
+
+	// MaxBackoff is the maximum delay between attempts.
+	// Defaults to DefaultMaxReconnectBackoff.
+	MaxBackoff time.Duration
+
+	// OnEvent, if not nil, is called for each connection lifecycle event.
+	// It's called from an internal goroutine so it must not block or call
+	// methods on the Client.
+	OnEvent func(ConnectionEvent)
+}
+
+// Reconnect enables automatic reconnection using policy.
+//
+// Once the connection is restored the last successful Login, Use or UsePort,
+// nickname change, by SetNick or ClientUpdate, and all active Register and RegisterChannel subscriptions are
+// replayed before any other commands are processed.
+func Reconnect(policy ReconnectPolicy) func(*Client) error {
+	return func(c *Client) error {
+		if policy.MaxAttempts < 0 {
+			return fmt.Errorf("reconnect: invalid max attempts %d", policy.MaxAttempts)
+		}
+		if policy.Backoff <= 0 {
+			policy.Backoff = DefaultReconnectBackoff
+		}
+		if policy.MaxBackoff <= 0 {
+			policy.MaxBackoff = DefaultMaxReconnectBackoff
+		}
+		c.reconnect = &policy
+		return nil
+	}
+}
+
+// ConnectionState is the state of a Client connection.
+type ConnectionState int
+
+const (
+	// ConnectionDisconnected indicates the connection to the server was lost.
+	ConnectionDisconnected ConnectionState = iota
+
+	// ConnectionReconnecting indicates a reconnect attempt is being made.
+	ConnectionReconnecting
+
+	// ConnectionRestored indicates the connection and session state were restored.
+	ConnectionRestored
+
+	// ConnectionFailed indicates all reconnect attempts failed and the
+	// Client is no longer usable.
+	ConnectionFailed
+)
+
+func (s ConnectionState) String() string {
+	switch s {
+	case ConnectionDisconnected:
+		return "disconnected"
+	case ConnectionReconnecting:
+		return "reconnecting"
+	case ConnectionRestored:
+		return "restored"
+	case ConnectionFailed:
+		return "failed"
+	default:
+		return fmt.Sprintf("ConnectionState(%d)", int(s))
+	}
+}
+
+// ConnectionEvent describes a change in a Client's connection state.
+type ConnectionEvent struct {
+	State ConnectionState
+
+	// Attempt is the reconnect attempt number, starting at 1,
+	// for ConnectionReconnecting and ConnectionRestored events.
+	Attempt int
+
+	// Err is the error which caused the last attempt to fail
+	// for ConnectionFailed events.
+	Err error
+}
+
+// event reports e to the reconnect policy handler if any.
+func (c *Client) event(e ConnectionEvent) {
+	if c.reconnect != nil && c.reconnect.OnEvent != nil {
+		c.reconnect.OnEvent(e)
+	}
+}
+
+// reconnectSession reconnects to the server and restores the session state.
+// It returns the new running session or nil if the client is closing or
+// the reconnect policy's attempts are exhausted.
+func (c *Client) reconnectSession() *session {
+	p := c.reconnect
+	backoff := p.Backoff
+	var err error
+	for attempt := 1; p.MaxAttempts == 0 || attempt <= p.MaxAttempts; attempt++ {
+		if attempt > 1 {
+			t := time.NewTimer(backoff)
+			select {
+			case <-t.C:
+			case <-c.closing:
+				t.Stop()
+				return nil
+			}
+
+			if backoff *= 2; backoff > p.MaxBackoff {
+				backoff = p.MaxBackoff
+			}
+		}
+
+		c.event(ConnectionEvent{State: ConnectionReconnecting, Attempt: attempt})
+		var s *session
+		if s, err = c.restoreSession(); err == nil {
+			c.event(ConnectionEvent{State: ConnectionRestored, Attempt: attempt})
+			return s
+		}
+	}
+
+	c.event(ConnectionEvent{State: ConnectionFailed, Err: err})
+	return nil
+}
+
+// restoreSession connects to the server and replays the session state,
+// returning the new session with its handlers running.
+func (c *Client) restoreSession() (*session, error) {
+	if err := c.connect(); err != nil {
+		c.closeConn() //nolint: errcheck
+		return nil, err
+	}
+
+	s := c.startSession()
+	for _, cmd := range c.state.commands() {
+		req := &request{cmd: cmd, response: make(chan response, 1)}
+		err := c.write([]byte(cmd))
+		if err == nil {
+			c.waitResponse(s, req)
+			err = (<-req.response).err
+		}
+
+		if err != nil {
+			s.close()
+			c.closeConn() //nolint: errcheck
+			s.wg.Wait()
+			return nil, fmt.Errorf("client: restore: %w", err)
+		}
+	}
+
+	if c.isClosing() {
+		s.close()
+		c.closeConn() //nolint: errcheck
+		s.wg.Wait()
+		return nil, errors.New("client: closing")
+	}
+
+	// Client IDs aren't preserved across connections.
+	c.ids.reset()
+	s.start(c.workHandler)
+
+	return s, nil
+}
+
+// sessionState records the commands needed to restore a session.
+type sessionState struct {
+	mtx     sync.Mutex
+	login   string
+	use     string
+	nick    string
+	events  []string
+	channel string
+}
+
+// setLogin records a successful login which resets subscriptions.
+func (s *sessionState) setLogin(cmd *Cmd) {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	s.login = cmd.String()
+	s.resetEvents()
+}
+
+// setLogout records a successful logout which resets all state.
+func (s *sessionState) setLogout() {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	s.login = ""
+	s.use = ""
+	s.nick = ""
+	s.resetEvents()
+}
+
+// setUse records a successful virtual server selection which resets
+// the nickname and subscriptions.
+func (s *sessionState) setUse(cmd *Cmd) {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	s.use = cmd.String()
+	s.nick = ""
+	s.resetEvents()
+}
+
+// setNick records a successful nickname change.
+func (s *sessionState) setNick(cmd *Cmd) {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	s.nick = cmd.String()
+}
+
+// addEvent records a successful event registration.
+func (s *sessionState) addEvent(cmd *Cmd) {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	str := cmd.String()
+	for _, e := range s.events {
+		if e == str {
+			return
+		}
+	}
+	s.events = append(s.events, str)
+}
+
+// setChannel records a successful channel event registration,
+// replacing any previous one as only one channel is supported.
+func (s *sessionState) setChannel(cmd *Cmd) {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	s.channel = cmd.String()
+}
+
+// setUnregister records a successful unregister of all events.
+func (s *sessionState) setUnregister() {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	s.resetEvents()
+}
+
+// resetEvents clears all event registrations.
+// Callers must hold s.mtx.
+func (s *sessionState) resetEvents() {
+	s.events = nil
+	s.channel = ""
+}
+
+// commands returns the commands needed to restore the session in order.
+func (s *sessionState) commands() []string {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	cmds := make([]string, 0, len(s.events)+4)
+	for _, cmd := range []string{s.login, s.use, s.nick} {
+		if cmd != "" {
+			cmds = append(cmds, cmd)
+		}
+	}
+	cmds = append(cmds, s.events...)
+	if s.channel != "" {
+		cmds = append(cmds, s.channel)
+	}
+
+	return cmds
+}
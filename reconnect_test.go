@@ -0,0 +1,142 @@
+package ts3
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// eventRecorder records connection events.
+type eventRecorder struct {
+	mtx    sync.Mutex
+	events []ConnectionEvent
+}
+
+func (r *eventRecorder) record(e ConnectionEvent) {
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+
+	r.events = append(r.events, e)
+}
+
+func (r *eventRecorder) states() []ConnectionState {
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+
+	states := make([]ConnectionState, len(r.events))
+	for i, e := range r.events {
+		states[i] = e.State
+	}
+	return states
+}
+
+func TestClientReconnect(t *testing.T) {
+	s := newServer(t)
+	defer func() {
+		assert.NoError(t, s.Close())
+	}()
+
+	r := &eventRecorder{}
+	c, err := NewClient(s.Addr,
+		Timeout(time.Second),
+		Reconnect(ReconnectPolicy{Backoff: time.Millisecond * 10, OnEvent: r.record}),
+	)
+	require.NoError(t, err)
+
+	defer func() {
+		assert.NoError(t, c.Close())
+	}()
+
+	require.NoError(t, c.Login("user", "pass"))
+	require.NoError(t, c.Use(1))
+	require.NoError(t, c.SetNick("bot"))
+	require.NoError(t, c.ClientUpdate(NewArg(ClientNickname, "bot2"), NewArg(ClientDescription, "desc")))
+	require.NoError(t, c.Register(ServerEvents))
+	require.NoError(t, c.Register(TextPrivateEvents))
+	require.NoError(t, c.RegisterChannel(5))
+
+	_, err = c.Exec("disconnect")
+	assert.Error(t, err)
+
+	// Commands issued while reconnecting are sent once restored.
+	v, err := c.Version()
+	require.NoError(t, err)
+	assert.Equal(t, "3.0.12.2", v.Version)
+	assert.True(t, c.IsConnected())
+
+	expected := []ConnectionState{ConnectionDisconnected, ConnectionReconnecting, ConnectionRestored}
+	assert.Equal(t, expected, r.states())
+
+	received := s.Received()
+	require.True(t, len(received) > 8)
+	assert.Equal(t, []string{
+		"login client_login_name=user client_login_password=pass",
+		"use sid=1",
+		"clientupdate client_nickname=bot2",
+		"servernotifyregister event=server",
+		"servernotifyregister event=textprivate",
+		"servernotifyregister event=channel id=5",
+		"version",
+	}, received[8:])
+}
+
+func TestClientReconnectFailed(t *testing.T) {
+	s := newServer(t)
+
+	r := &eventRecorder{}
+	c, err := NewClient(s.Addr,
+		Timeout(time.Millisecond*100),
+		Reconnect(ReconnectPolicy{MaxAttempts: 2, Backoff: time.Millisecond * 10, OnEvent: r.record}),
+	)
+	require.NoError(t, err)
+
+	// Stop the server so reconnects fail.
+	assert.NoError(t, s.Close())
+
+	_, err = c.Exec("version")
+	assert.Error(t, err)
+
+	assert.Eventually(t, func() bool {
+		_, err := c.Exec("version")
+		return err == ErrNotConnected
+	}, time.Second, time.Millisecond*10)
+	assert.False(t, c.IsConnected())
+
+	expected := []ConnectionState{
+		ConnectionDisconnected,
+		ConnectionReconnecting,
+		ConnectionReconnecting,
+		ConnectionFailed,
+	}
+	assert.Equal(t, expected, r.states())
+	r.mtx.Lock()
+	assert.Error(t, r.events[3].Err)
+	r.mtx.Unlock()
+
+	assert.Error(t, c.Close())
+}
+
+func TestSessionState(t *testing.T) {
+	var s sessionState
+	s.setLogin(NewCmd("login"))
+	s.setUse(NewCmd("use"))
+	s.setNick(NewCmd("nick"))
+	s.addEvent(NewCmd("event1"))
+	s.addEvent(NewCmd("event1"))
+	s.setChannel(NewCmd("channel1"))
+	s.setChannel(NewCmd("channel2"))
+	assert.Equal(t, []string{"login\n", "use\n", "nick\n", "event1\n", "channel2\n"}, s.commands())
+
+	s.setUse(NewCmd("use2"))
+	assert.Equal(t, []string{"login\n", "use2\n"}, s.commands())
+
+	s.addEvent(NewCmd("event2"))
+	s.setUnregister()
+	assert.Equal(t, []string{"login\n", "use2\n"}, s.commands())
+
+	s.setLogout()
+	assert.Empty(t, s.commands())
+}
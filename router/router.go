@@ -0,0 +1,371 @@
+// Package router provides a chat bot command router for TeamSpeak 3
+// text messages.
+//
+// Messages starting with the configured prefix are split into a command
+// name and arguments and dispatched to the handler registered for the
+// name e.g.
+//
+//	r, err := router.New(c, router.Prefix("!"))
+//	if err != nil {
+//		log.Fatal(err)
+//	}
+//
+//	r.Handle("ping", func(req *router.Request) error {
+//		return req.Reply("pong")
+//	})
+//	r.Handle("kick", kick).Groups(6)
+//
+//	if err := r.Start(context.Background()); err != nil {
+//		log.Fatal(err)
+//	}
+//	defer r.Close()
+package router
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/multiplay/go-ts3"
+)
+
+const (
+	// DefaultPrefix is the default prefix which identifies commands.
+	DefaultPrefix = "!"
+
+	// DefaultQuotes are the default characters which quote arguments.
+	DefaultQuotes = `"'`
+)
+
+var (
+	// ErrPermissionDenied is passed to the error handler when the invoker
+	// isn't a member of any of the server groups required by a command.
+	ErrPermissionDenied = errors.New("permission denied")
+
+	// ErrUnterminatedQuote is passed to the error handler when a command
+	// contains an opening quote without a matching closing quote.
+	ErrUnterminatedQuote = errors.New("unterminated quote")
+)
+
+// Handler handles a command.
+// A non-nil error is passed to the router's error handler.
+type Handler func(req *Request) error
+
+// server is the subset of ts3.ServerMethods used by the router.
+type server interface {
+	SendTextMessageContext(ctx context.Context, mode ts3.TextMessageTargetMode, target int, msg string) error
+	ClientInfoContext(ctx context.Context, id int) (*ts3.ClientDetails, error)
+}
+
+// Request is a command received by the router.
+type Request struct {
+	ts3.Invoker
+
+	// Command is the lower case name of the command.
+	Command string
+
+	// Args are the arguments following the command name.
+	Args []string
+
+	// Message is the complete text message including the prefix.
+	Message string
+
+	// TargetMode is how the message was sent to the bot.
+	TargetMode ts3.TextMessageTargetMode
+
+	ctx    context.Context
+	router *Router
+	target int
+}
+
+// Context returns the context passed to Start.
+func (req *Request) Context() context.Context {
+	return req.ctx
+}
+
+// Reply sends msg to the same target as the request i.e. privately to the
+// invoker, to the channel or to the server.
+func (req *Request) Reply(msg string) error {
+	return req.router.srv.SendTextMessageContext(req.ctx, req.TargetMode, req.target, msg)
+}
+
+// Replyf formats according to a format specifier and sends the result
+// using Reply.
+func (req *Request) Replyf(format string, a ...interface{}) error {
+	return req.Reply(fmt.Sprintf(format, a...))
+}
+
+// Route is a command registered with a Router.
+type Route struct {
+	router  *Router
+	handler Handler
+	groups  []int
+}
+
+// Groups restricts the command to clients which are a member of at least
+// one of the server groups ids.
+func (rt *Route) Groups(ids ...int) *Route {
+	rt.router.mtx.Lock()
+	defer rt.router.mtx.Unlock()
+
+	rt.groups = append(rt.groups, ids...)
+	return rt
+}
+
+// Router dispatches text message commands to handlers.
+type Router struct {
+	c          *ts3.Client
+	srv        server
+	prefix     string
+	quotes     string
+	categories []ts3.NotifyCategory
+	notFound   Handler
+	onError    func(*Request, error)
+
+	// Below here is protected by mtx.
+	mtx       sync.RWMutex
+	routes    map[string]*Route
+	self      int
+	channelID int
+	serverID  int
+	sub       *ts3.Subscription
+}
+
+// Prefix sets the prefix which identifies commands, defaults to DefaultPrefix.
+func Prefix(prefix string) func(*Router) error {
+	return func(r *Router) error {
+		r.prefix = prefix
+		return nil
+	}
+}
+
+// Quotes sets the characters which can be used to quote arguments
+// containing white space, defaults to DefaultQuotes.
+// Within and outside of quotes a backslash escapes the following character.
+func Quotes(quotes string) func(*Router) error {
+	return func(r *Router) error {
+		r.quotes = quotes
+		return nil
+	}
+}
+
+// Categories sets the text events which the router registers for,
+// defaults to ts3.TextPrivateEvents, ts3.TextChannelEvents and
+// ts3.TextServerEvents.
+func Categories(categories ...ts3.NotifyCategory) func(*Router) error {
+	return func(r *Router) error {
+		for _, c := range categories {
+			switch c {
+			case ts3.TextPrivateEvents, ts3.TextChannelEvents, ts3.TextServerEvents:
+			default:
+				return fmt.Errorf("categories: invalid text category %q", c)
+			}
+		}
+		r.categories = categories
+		return nil
+	}
+}
+
+// NotFound sets the handler called for commands which aren't registered.
+// By default unknown commands are ignored.
+func NotFound(h Handler) func(*Router) error {
+	return func(r *Router) error {
+		r.notFound = h
+		return nil
+	}
+}
+
+// ErrorHandler sets the function called when parsing, the permission
+// check or a handler fails. By default the error is sent as a reply.
+func ErrorHandler(f func(*Request, error)) func(*Router) error {
+	return func(r *Router) error {
+		r.onError = f
+		return nil
+	}
+}
+
+// New returns a new Router which receives commands using c.
+// Start must be called before commands are processed.
+func New(c *ts3.Client, options ...func(*Router) error) (*Router, error) {
+	r, err := newRouter(c.Server, options...)
+	if err != nil {
+		return nil, err
+	}
+
+	r.c = c
+	return r, nil
+}
+
+// newRouter returns a new Router which uses srv to process commands.
+func newRouter(srv server, options ...func(*Router) error) (*Router, error) {
+	r := &Router{
+		srv:    srv,
+		prefix: DefaultPrefix,
+		quotes: DefaultQuotes,
+		categories: []ts3.NotifyCategory{
+			ts3.TextPrivateEvents,
+			ts3.TextChannelEvents,
+			ts3.TextServerEvents,
+		},
+		onError: replyError,
+		routes:  make(map[string]*Route),
+	}
+	for _, f := range options {
+		if f == nil {
+			return nil, ts3.ErrNilOption
+		}
+		if err := f(r); err != nil {
+			return nil, err
+		}
+	}
+
+	return r, nil
+}
+
+// replyError is the default error handler.
+func replyError(req *Request, err error) {
+	req.Reply("error: " + err.Error()) //nolint: errcheck
+}
+
+// Handle registers h to handle the command name.
+// Names are matched case insensitively.
+func (r *Router) Handle(name string, h Handler) *Route {
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+
+	rt := &Route{router: r, handler: h}
+	r.routes[strings.ToLower(name)] = rt
+	return rt
+}
+
+// Start registers for the configured text events and starts dispatching
+// commands. ctx is made available to handlers by Request.Context.
+//
+// Replies to channel and server messages are sent to the channel and
+// virtual server the client is in when Start is called.
+func (r *Router) Start(ctx context.Context) error {
+	info, err := r.c.WhoamiContext(ctx)
+	if err != nil {
+		return err
+	}
+
+	r.mtx.Lock()
+	r.self = info.ClientID
+	r.channelID = info.ClientChannelID
+	r.serverID = info.ServerID
+	r.mtx.Unlock()
+
+	for _, c := range r.categories {
+		if err := r.c.RegisterContext(ctx, c); err != nil {
+			return err
+		}
+	}
+
+	sub := r.c.OnTextMessage(func(m *ts3.TextMessage) {
+		r.handle(ctx, m)
+	})
+
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+
+	r.sub = sub
+	return nil
+}
+
+// Close stops dispatching commands.
+// The text event registrations are left in place.
+func (r *Router) Close() {
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+
+	if r.sub != nil {
+		r.sub.Unsubscribe()
+		r.sub = nil
+	}
+}
+
+// handle processes the text message m.
+func (r *Router) handle(ctx context.Context, m *ts3.TextMessage) {
+	r.mtx.RLock()
+	self, channelID, serverID := r.self, r.channelID, r.serverID
+	r.mtx.RUnlock()
+
+	if m.InvokerID == self || !strings.HasPrefix(m.Message, r.prefix) {
+		return
+	}
+
+	req := &Request{
+		Invoker:    m.Invoker,
+		Message:    m.Message,
+		TargetMode: m.TargetMode,
+		ctx:        ctx,
+		router:     r,
+	}
+	switch m.TargetMode {
+	case ts3.TextMessageTargetClient:
+		req.target = m.InvokerID
+	case ts3.TextMessageTargetChannel:
+		req.target = channelID
+	case ts3.TextMessageTargetServer:
+		req.target = serverID
+	}
+
+	fields, err := split(m.Message[len(r.prefix):], r.quotes)
+	if err != nil {
+		r.onError(req, err)
+		return
+	} else if len(fields) == 0 {
+		return
+	}
+
+	req.Command = strings.ToLower(fields[0])
+	req.Args = fields[1:]
+
+	r.mtx.RLock()
+	rt := r.routes[req.Command]
+	var groups []int
+	if rt != nil {
+		groups = rt.groups
+	}
+	r.mtx.RUnlock()
+
+	h := r.notFound
+	if rt != nil {
+		h = rt.handler
+	}
+
+	if h == nil {
+		return
+	}
+
+	if len(groups) > 0 {
+		if err := r.authorize(req, groups); err != nil {
+			r.onError(req, err)
+			return
+		}
+	}
+
+	if err := h(req); err != nil {
+		r.onError(req, err)
+	}
+}
+
+// authorize returns nil if the invoker of req is a member of one of groups.
+func (r *Router) authorize(req *Request, groups []int) error {
+	info, err := r.srv.ClientInfoContext(req.ctx, req.InvokerID)
+	if err != nil {
+		return err
+	}
+
+	for _, id := range info.ServerGroups {
+		for _, g := range groups {
+			if id == g {
+				return nil
+			}
+		}
+	}
+
+	return ErrPermissionDenied
+}
@@ -0,0 +1,178 @@
+package router
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/multiplay/go-ts3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// sent is a text message sent by fakeServer.
+type sent struct {
+	mode   ts3.TextMessageTargetMode
+	target int
+	msg    string
+}
+
+// fakeServer is a server which records the messages sent.
+type fakeServer struct {
+	sent   []sent
+	groups map[int][]int
+}
+
+func (s *fakeServer) SendTextMessageContext(ctx context.Context, mode ts3.TextMessageTargetMode, target int, msg string) error {
+	s.sent = append(s.sent, sent{mode: mode, target: target, msg: msg})
+	return nil
+}
+
+func (s *fakeServer) ClientInfoContext(ctx context.Context, id int) (*ts3.ClientDetails, error) {
+	groups, ok := s.groups[id]
+	if !ok {
+		return nil, errors.New("invalid clientID")
+	}
+	return &ts3.ClientDetails{ServerGroups: groups}, nil
+}
+
+func newTestRouter(t *testing.T, options ...func(*Router) error) (*Router, *fakeServer) {
+	t.Helper()
+	srv := &fakeServer{groups: map[int][]int{5: {8}, 6: {6, 8}}}
+	r, err := newRouter(srv, options...)
+	require.NoError(t, err)
+	r.self, r.channelID, r.serverID = 1, 10, 100
+	return r, srv
+}
+
+func message(mode ts3.TextMessageTargetMode, invoker int, msg string) *ts3.TextMessage {
+	return &ts3.TextMessage{
+		TargetMode: mode,
+		Message:    msg,
+		Invoker: ts3.Invoker{
+			InvokerID:   invoker,
+			InvokerName: "bob",
+			InvokerUID:  "xyz=",
+		},
+	}
+}
+
+func TestRouterDispatch(t *testing.T) {
+	r, srv := newTestRouter(t)
+
+	var got *Request
+	r.Handle("Echo", func(req *Request) error {
+		got = req
+		return req.Reply(req.Args[0])
+	})
+
+	ctx := context.Background()
+	r.handle(ctx, message(ts3.TextMessageTargetClient, 5, `!ECHO "hello world" x`))
+	require.NotNil(t, got)
+	assert.Equal(t, "echo", got.Command)
+	assert.Equal(t, []string{"hello world", "x"}, got.Args)
+	assert.Equal(t, `!ECHO "hello world" x`, got.Message)
+	assert.Equal(t, ts3.Invoker{InvokerID: 5, InvokerName: "bob", InvokerUID: "xyz="}, got.Invoker)
+	assert.Equal(t, ctx, got.Context())
+
+	r.handle(ctx, message(ts3.TextMessageTargetChannel, 5, "!echo a"))
+	r.handle(ctx, message(ts3.TextMessageTargetServer, 5, "!echo b"))
+
+	expected := []sent{
+		{mode: ts3.TextMessageTargetClient, target: 5, msg: "hello world"},
+		{mode: ts3.TextMessageTargetChannel, target: 10, msg: "a"},
+		{mode: ts3.TextMessageTargetServer, target: 100, msg: "b"},
+	}
+	assert.Equal(t, expected, srv.sent)
+}
+
+func TestRouterIgnored(t *testing.T) {
+	r, srv := newTestRouter(t, Prefix("."))
+
+	var calls int
+	r.Handle("ping", func(req *Request) error {
+		calls++
+		return nil
+	})
+
+	ctx := context.Background()
+	r.handle(ctx, message(ts3.TextMessageTargetClient, 5, "ping"))
+	r.handle(ctx, message(ts3.TextMessageTargetClient, 5, "!ping"))
+	r.handle(ctx, message(ts3.TextMessageTargetClient, 5, "."))
+	r.handle(ctx, message(ts3.TextMessageTargetClient, 5, ".unknown"))
+	r.handle(ctx, message(ts3.TextMessageTargetClient, 1, ".ping")) // Own message.
+	assert.Equal(t, 0, calls)
+	assert.Empty(t, srv.sent)
+
+	r.handle(ctx, message(ts3.TextMessageTargetClient, 5, ".ping"))
+	assert.Equal(t, 1, calls)
+}
+
+func TestRouterNotFound(t *testing.T) {
+	var got string
+	r, _ := newTestRouter(t, NotFound(func(req *Request) error {
+		got = req.Command
+		return nil
+	}))
+
+	r.handle(context.Background(), message(ts3.TextMessageTargetClient, 5, "!unknown"))
+	assert.Equal(t, "unknown", got)
+}
+
+func TestRouterGroups(t *testing.T) {
+	var errs []error
+	r, srv := newTestRouter(t, ErrorHandler(func(req *Request, err error) {
+		errs = append(errs, err)
+	}))
+
+	var calls int
+	r.Handle("kick", func(req *Request) error {
+		calls++
+		return nil
+	}).Groups(6, 7)
+
+	ctx := context.Background()
+	r.handle(ctx, message(ts3.TextMessageTargetClient, 6, "!kick"))
+	assert.Equal(t, 1, calls)
+	assert.Empty(t, errs)
+
+	r.handle(ctx, message(ts3.TextMessageTargetClient, 5, "!kick"))
+	assert.Equal(t, 1, calls)
+	assert.Equal(t, []error{ErrPermissionDenied}, errs)
+
+	r.handle(ctx, message(ts3.TextMessageTargetClient, 9, "!kick"))
+	assert.Equal(t, 1, calls)
+	assert.Len(t, errs, 2)
+	assert.Empty(t, srv.sent)
+}
+
+func TestRouterErrors(t *testing.T) {
+	r, srv := newTestRouter(t)
+
+	r.Handle("fail", func(req *Request) error {
+		return errors.New("failed")
+	})
+
+	ctx := context.Background()
+	r.handle(ctx, message(ts3.TextMessageTargetClient, 5, "!fail"))
+	r.handle(ctx, message(ts3.TextMessageTargetChannel, 5, `!fail "x`))
+
+	expected := []sent{
+		{mode: ts3.TextMessageTargetClient, target: 5, msg: "error: failed"},
+		{mode: ts3.TextMessageTargetChannel, target: 10, msg: "error: unterminated quote"},
+	}
+	assert.Equal(t, expected, srv.sent)
+}
+
+func TestRouterOptions(t *testing.T) {
+	_, err := newRouter(&fakeServer{}, nil)
+	assert.Equal(t, ts3.ErrNilOption, err)
+
+	_, err = newRouter(&fakeServer{}, Categories(ts3.ServerEvents))
+	assert.Error(t, err)
+
+	r, err := newRouter(&fakeServer{}, Categories(ts3.TextPrivateEvents), Quotes("`"))
+	require.NoError(t, err)
+	assert.Equal(t, []ts3.NotifyCategory{ts3.TextPrivateEvents}, r.categories)
+	assert.Equal(t, "`", r.quotes)
+}
@@ -0,0 +1,56 @@
+package router
+
+import (
+	"strings"
+	"unicode"
+)
+
+// split splits s into fields separated by white space.
+// Any of the characters in quotes can be used to quote a field containing
+// white space and a backslash escapes the following character.
+func split(s, quotes string) ([]string, error) {
+	var fields []string
+	var b strings.Builder
+	var quote rune
+	var inField, escaped bool
+	for _, r := range s {
+		switch {
+		case escaped:
+			b.WriteRune(r)
+			escaped = false
+		case r == '\\':
+			inField, escaped = true, true
+		case quote != 0:
+			if r == quote {
+				quote = 0
+			} else {
+				b.WriteRune(r)
+			}
+		case strings.ContainsRune(quotes, r):
+			inField, quote = true, r
+		case unicode.IsSpace(r):
+			if inField {
+				fields = append(fields, b.String())
+				b.Reset()
+				inField = false
+			}
+		default:
+			inField = true
+			b.WriteRune(r)
+		}
+	}
+
+	switch {
+	case quote != 0:
+		return nil, ErrUnterminatedQuote
+	case escaped:
+		// Trailing backslash is kept as is.
+		b.WriteRune('\\')
+	}
+
+	if inField {
+		fields = append(fields, b.String())
+	}
+
+	return fields, nil
+}
@@ -0,0 +1,80 @@
+package router
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSplit(t *testing.T) {
+	tests := map[string]struct {
+		s        string
+		quotes   string
+		expected []string
+		err      error
+	}{
+		"empty": {
+			s: "",
+		},
+		"spaces": {
+			s: "   ",
+		},
+		"simple": {
+			s:        "kick  bob\tnow ",
+			expected: []string{"kick", "bob", "now"},
+		},
+		"double-quoted": {
+			s:        `say "hello world" x`,
+			expected: []string{"say", "hello world", "x"},
+		},
+		"single-quoted": {
+			s:        `say 'he said "hi"'`,
+			expected: []string{"say", `he said "hi"`},
+		},
+		"adjacent-quotes": {
+			s:        `a"b c"d`,
+			expected: []string{"ab cd"},
+		},
+		"empty-quoted": {
+			s:        `say ""`,
+			expected: []string{"say", ""},
+		},
+		"escaped": {
+			s:        `say hello\ world \"x\\`,
+			expected: []string{"say", "hello world", `"x\`},
+		},
+		"escaped-in-quotes": {
+			s:        `say "a \" b"`,
+			expected: []string{"say", `a " b`},
+		},
+		"trailing-backslash": {
+			s:        `say x\`,
+			expected: []string{"say", `x\`},
+		},
+		"custom-quotes": {
+			s:        "say `a b` 'c",
+			quotes:   "`",
+			expected: []string{"say", "a b", "'c"},
+		},
+		"unterminated": {
+			s:   `say "hello`,
+			err: ErrUnterminatedQuote,
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			quotes := tc.quotes
+			if quotes == "" {
+				quotes = DefaultQuotes
+			}
+			fields, err := split(tc.s, quotes)
+			if tc.err != nil {
+				assert.Equal(t, tc.err, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expected, fields)
+		})
+	}
+}
@@ -1,6 +1,10 @@
 package ts3
 
 import (
+	"context"
+	"errors"
+	"sort"
+	"strings"
 	"time"
 )
 
@@ -167,6 +171,11 @@ type Server struct {
 // In addition to the options supported by the Teamspeak 3 query protocol it also supports the ExtendedServerList option.
 // If ExtendedServerList is specified in options then each server returned contain extended server information as returned by Info.
 func (s *ServerMethods) List(options ...string) (servers []*Server, err error) {
+	return s.ListContext(context.Background(), options...)
+}
+
+// ListContext lists virtual servers.
+func (s *ServerMethods) ListContext(ctx context.Context, options ...string) (servers []*Server, err error) {
 	var extended bool
 	for i, o := range options {
 		if o == ExtendedServerList {
@@ -175,20 +184,21 @@ func (s *ServerMethods) List(options ...string) (servers []*Server, err error) {
 		}
 	}
 
-	if _, err = s.ExecCmd(NewCmd("serverlist").WithOptions(options...).WithResponse(&servers)); err != nil {
+	if _, err = s.ExecCmdContext(ctx, NewCmd("serverlist").WithOptions(options...).WithResponse(&servers)); err != nil {
 		return nil, err
 	}
 
 	if extended {
 		var info *ConnectionInfo
-		if info, err = s.Whoami(); err != nil {
+		if info, err = s.WhoamiContext(ctx); err != nil {
 			return nil, err
 		}
 
 		var lastID int
 		defer func() {
 			if lastID != info.ServerID {
-				// Restore the previously selected server
+				// Restore the previously selected server, even if ctx is
+				// done, so the connection is left in a consistent state.
 				if err2 := s.Use(info.ServerID); err2 != nil && err != nil {
 					err = err2
 				}
@@ -196,12 +206,12 @@ func (s *ServerMethods) List(options ...string) (servers []*Server, err error) {
 		}()
 
 		for _, server := range servers {
-			if err = s.Use(server.ID); err != nil {
+			if err = s.UseContext(ctx, server.ID); err != nil {
 				return nil, err
 			}
 			lastID = server.ID
 
-			if _, err = s.ExecCmd(NewCmd("serverinfo").WithResponse(server)); err != nil {
+			if _, err = s.ExecCmdContext(ctx, NewCmd("serverinfo").WithResponse(server)); err != nil {
 				return nil, err
 			}
 		}
@@ -212,17 +222,27 @@ func (s *ServerMethods) List(options ...string) (servers []*Server, err error) {
 
 // IDGetByPort returns the database id of the virtual server running on UDP port.
 func (s *ServerMethods) IDGetByPort(port uint16) (int, error) {
+	return s.IDGetByPortContext(context.Background(), port)
+}
+
+// IDGetByPortContext returns the database id of the virtual server running on UDP port.
+func (s *ServerMethods) IDGetByPortContext(ctx context.Context, port uint16) (int, error) {
 	r := struct {
 		ID int `ms:"server_id"`
 	}{}
-	_, err := s.ExecCmd(NewCmd("serveridgetbyport").WithArgs(NewArg("virtualserver_port", port)).WithResponse(&r))
+	_, err := s.ExecCmdContext(ctx, NewCmd("serveridgetbyport").WithArgs(NewArg("virtualserver_port", port)).WithResponse(&r))
 	return r.ID, err
 }
 
 // Info returns detailed configuration information about the selected server.
 func (s *ServerMethods) Info() (*Server, error) {
+	return s.InfoContext(context.Background())
+}
+
+// InfoContext returns detailed configuration information about the selected server.
+func (s *ServerMethods) InfoContext(ctx context.Context) (*Server, error) {
 	r := &Server{}
-	if _, err := s.ExecCmd(NewCmd("serverinfo").WithResponse(&r)); err != nil {
+	if _, err := s.ExecCmdContext(ctx, NewCmd("serverinfo").WithResponse(&r)); err != nil {
 		return nil, err
 	}
 
@@ -231,8 +251,13 @@ func (s *ServerMethods) Info() (*Server, error) {
 
 // InstanceInfo returns detailed information about the selected instance.
 func (s *ServerMethods) InstanceInfo() (*Instance, error) {
+	return s.InstanceInfoContext(context.Background())
+}
+
+// InstanceInfoContext returns detailed information about the selected instance.
+func (s *ServerMethods) InstanceInfoContext(ctx context.Context) (*Instance, error) {
 	r := &Instance{}
-	if _, err := s.ExecCmd(NewCmd("instanceinfo").WithResponse(&r)); err != nil {
+	if _, err := s.ExecCmdContext(ctx, NewCmd("instanceinfo").WithResponse(&r)); err != nil {
 		return nil, err
 	}
 
@@ -241,8 +266,13 @@ func (s *ServerMethods) InstanceInfo() (*Instance, error) {
 
 // ServerConnectionInfo returns detailed bandwidth and transfer information about the selected instance.
 func (s *ServerMethods) ServerConnectionInfo() (*ServerConnectionInfo, error) {
+	return s.ServerConnectionInfoContext(context.Background())
+}
+
+// ServerConnectionInfoContext returns detailed bandwidth and transfer information about the selected instance.
+func (s *ServerMethods) ServerConnectionInfoContext(ctx context.Context) (*ServerConnectionInfo, error) {
 	r := &ServerConnectionInfo{}
-	if _, err := s.ExecCmd(NewCmd("serverrequestconnectioninfo").WithResponse(&r)); err != nil {
+	if _, err := s.ExecCmdContext(ctx, NewCmd("serverrequestconnectioninfo").WithResponse(&r)); err != nil {
 		return nil, err
 	}
 
@@ -251,14 +281,24 @@ func (s *ServerMethods) ServerConnectionInfo() (*ServerConnectionInfo, error) {
 
 // Edit changes the selected virtual servers configuration using the given args.
 func (s *ServerMethods) Edit(args ...CmdArg) error {
-	_, err := s.ExecCmd(NewCmd("serveredit").WithArgs(args...))
+	return s.EditContext(context.Background(), args...)
+}
+
+// EditContext changes the selected virtual servers configuration using the given args.
+func (s *ServerMethods) EditContext(ctx context.Context, args ...CmdArg) error {
+	_, err := s.ExecCmdContext(ctx, NewCmd("serveredit").WithArgs(args...))
 	return err
 }
 
 // Delete deletes the virtual server specified by id.
 // Only virtual server in a stopped state can be deleted.
 func (s *ServerMethods) Delete(id int) error {
-	_, err := s.ExecCmd(NewCmd("serverdelete").WithArgs(NewArg("sid", id)))
+	return s.DeleteContext(context.Background(), id)
+}
+
+// DeleteContext deletes the virtual server specified by id.
+func (s *ServerMethods) DeleteContext(ctx context.Context, id int) error {
+	_, err := s.ExecCmdContext(ctx, NewCmd("serverdelete").WithArgs(NewArg("sid", id)))
 	return err
 }
 
@@ -274,9 +314,15 @@ type CreatedServer struct {
 // If virtualserver_port arg is not specified, the server will use the first unused
 // UDP port.
 func (s *ServerMethods) Create(name string, args ...CmdArg) (*CreatedServer, error) {
+	return s.CreateContext(context.Background(), name, args...)
+}
+
+// CreateContext creates a new virtual server using the given properties and returns
+// its ID, port and initial administrator privilege key.
+func (s *ServerMethods) CreateContext(ctx context.Context, name string, args ...CmdArg) (*CreatedServer, error) {
 	r := &CreatedServer{}
 	args = append(args, NewArg("virtualserver_name", name))
-	if _, err := s.ExecCmd(NewCmd("servercreate").WithArgs(args...).WithResponse(r)); err != nil {
+	if _, err := s.ExecCmdContext(ctx, NewCmd("servercreate").WithArgs(args...).WithResponse(r)); err != nil {
 		return nil, err
 	}
 
@@ -285,91 +331,192 @@ func (s *ServerMethods) Create(name string, args ...CmdArg) (*CreatedServer, err
 
 // Start starts the virtual server specified by id.
 func (s *ServerMethods) Start(id int) error {
-	_, err := s.ExecCmd(NewCmd("serverstart").WithArgs(NewArg("sid", id)))
+	return s.StartContext(context.Background(), id)
+}
+
+// StartContext starts the virtual server specified by id.
+func (s *ServerMethods) StartContext(ctx context.Context, id int) error {
+	_, err := s.ExecCmdContext(ctx, NewCmd("serverstart").WithArgs(NewArg("sid", id)))
 	return err
 }
 
 // Stop stops the virtual server specified by id.
 func (s *ServerMethods) Stop(id int) error {
-	_, err := s.ExecCmd(NewCmd("serverstop").WithArgs(NewArg("sid", id)))
+	return s.StopContext(context.Background(), id)
+}
+
+// StopContext stops the virtual server specified by id.
+func (s *ServerMethods) StopContext(ctx context.Context, id int) error {
+	_, err := s.ExecCmdContext(ctx, NewCmd("serverstop").WithArgs(NewArg("sid", id)))
 	return err
 }
 
-// Group represents a virtual server group.
-type Group struct {
-	ID                int `ms:"sgid"`
-	Name              string
-	Type              int
-	IconID            int
-	Saved             bool `ms:"savedb"`
-	SortID            int
-	NameMode          int
-	ModifyPower       int `ms:"n_modifyp"`
-	MemberAddPower    int `ms:"n_member_addp"`
-	MemberRemovePower int `ms:"n_member_addp"`
-}
-
-// GroupList returns a list of available groups for the selected server.
-func (s *ServerMethods) GroupList() ([]*Group, error) {
-	var groups []*Group
-	if _, err := s.ExecCmd(NewCmd("servergrouplist").WithResponse(&groups)); err != nil {
-		return nil, err
-	}
+// TokenType is the type of group a privilege key grants.
+type TokenType int
 
-	return groups, nil
+const (
+	// TokenServerGroup keys grant a server group.
+	TokenServerGroup TokenType = iota
+	// TokenChannelGroup keys grant a channel group in a channel.
+	TokenChannelGroup
+)
+
+// PrivilegeKeyTarget is the group a privilege key grants.
+// It's implemented by ServerGroupToken and ChannelGroupToken.
+type PrivilegeKeyTarget interface {
+	CmdArg
+	privilegeKeyTarget()
 }
 
-// Channel represents a TeamSpeak 3 channel in a virtual server.
-type Channel struct {
-	ID                   int    `ms:"cid"`
-	ParentID             int    `ms:"pid"`
-	ChannelOrder         int    `ms:"channel_order"`
-	ChannelName          string `ms:"channel_name"`
-	TotalClients         int    `ms:"total_clients"`
-	NeededSubscribePower int    `ms:"channel_needed_subscribe_power"`
+// ServerGroupToken is a PrivilegeKeyTarget which grants the server group GroupID.
+type ServerGroupToken struct {
+	GroupID int
 }
 
-// ChannelList returns a list of channels for the selected server.
-func (s *ServerMethods) ChannelList() ([]*Channel, error) {
-	var channels []*Channel
-	if _, err := s.ExecCmd(NewCmd("channellist").WithResponse(&channels)); err != nil {
-		return nil, err
+// ArgString implements CmdArg.
+func (t ServerGroupToken) ArgString() string {
+	return NewArgSet(
+		NewArg("tokentype", int(TokenServerGroup)),
+		NewArg("tokenid1", t.GroupID),
+		NewArg("tokenid2", 0),
+	).ArgString()
+}
+
+func (ServerGroupToken) privilegeKeyTarget() {}
+
+// ChannelGroupToken is a PrivilegeKeyTarget which grants the channel group
+// GroupID in the channel ChannelID.
+type ChannelGroupToken struct {
+	GroupID   int
+	ChannelID int
+}
+
+// ArgString implements CmdArg.
+func (t ChannelGroupToken) ArgString() string {
+	return NewArgSet(
+		NewArg("tokentype", int(TokenChannelGroup)),
+		NewArg("tokenid1", t.GroupID),
+		NewArg("tokenid2", t.ChannelID),
+	).ArgString()
+}
+
+func (ChannelGroupToken) privilegeKeyTarget() {}
+
+// PrivilegeKeyDescription is the description of a privilege key.
+// It can be passed to PrivilegeKeyAdd.
+type PrivilegeKeyDescription string
+
+// ArgString implements CmdArg.
+func (d PrivilegeKeyDescription) ArgString() string {
+	return NewArg("tokendescription", string(d)).ArgString()
+}
+
+// PrivilegeKeyCustomSet is a set of custom client properties, keyed by
+// identifier, which are stored for the client which uses a privilege key.
+// It can be passed to PrivilegeKeyAdd.
+type PrivilegeKeyCustomSet map[string]string
+
+// ArgString implements CmdArg.
+func (cs PrivilegeKeyCustomSet) ArgString() string {
+	idents := make([]string, 0, len(cs))
+	for k := range cs {
+		idents = append(idents, k)
+	}
+	sort.Strings(idents)
+
+	// The set is encoded as a list which is then escaped as a single value.
+	set := make([]string, len(idents))
+	for i, k := range idents {
+		set[i] = NewArgSet(NewArg("ident", k), NewArg("value", cs[k])).ArgString()
 	}
 
-	return channels, nil
+	return NewArg("tokencustomset", strings.Join(set, "|")).ArgString()
 }
 
 // PrivilegeKey represents a server privilege key.
 type PrivilegeKey struct {
 	Token       string
-	Type        int    `ms:"token_type"`
-	ID1         int    `ms:"token_id1"`
-	ID2         int    `ms:"token_id2"`
-	Created     int    `ms:"token_created"`
-	Description string `ms:"token_description"`
+	Type        TokenType `ms:"token_type"`
+	ID1         int       `ms:"token_id1"` // The group ID.
+	ID2         int       `ms:"token_id2"` // The channel ID for TokenChannelGroup keys.
+	Created     time.Time `ms:"token_created"`
+	Description string    `ms:"token_description"`
+}
+
+// Target returns the group the key grants.
+func (k *PrivilegeKey) Target() PrivilegeKeyTarget {
+	if k.Type == TokenChannelGroup {
+		return ChannelGroupToken{GroupID: k.ID1, ChannelID: k.ID2}
+	}
+	return ServerGroupToken{GroupID: k.ID1}
 }
 
 // PrivilegeKeyList returns a list of available privilege keys for the selected server,
 // including their type and group IDs.
 func (s *ServerMethods) PrivilegeKeyList() ([]*PrivilegeKey, error) {
+	return s.PrivilegeKeyListContext(context.Background())
+}
+
+// PrivilegeKeyListContext returns a list of available privilege keys for the selected server.
+func (s *ServerMethods) PrivilegeKeyListContext(ctx context.Context) ([]*PrivilegeKey, error) {
 	var keys []*PrivilegeKey
-	if _, err := s.ExecCmd(NewCmd("privilegekeylist").WithResponse(&keys)); err != nil {
+	if _, err := s.ExecCmdContext(ctx, NewCmd("privilegekeylist").WithResponse(&keys)); err != nil {
+		if isEmptyResult(err) {
+			return nil, nil
+		}
 		return nil, err
 	}
 
 	return keys, nil
 }
 
-// PrivilegeKeyAdd creates a new privilege token to the selected server and returns it.
-// If tokentype is set to 0, the ID specified with id1 will be a server group ID.
-// Otherwise, id1 is used as a channel group ID and you need to provide a valid channel ID using id2.
-func (s *ServerMethods) PrivilegeKeyAdd(ttype, id1, id2 int, options ...CmdArg) (string, error) {
+// PrivilegeKeyAdd creates a new privilege key on the selected server which
+// grants target and returns it e.g.
+// PrivilegeKeyAdd(ChannelGroupToken{GroupID: 5, ChannelID: 2}, PrivilegeKeyDescription("mods")).
+// It accepts PrivilegeKeyDescription and PrivilegeKeyCustomSet options.
+func (s *ServerMethods) PrivilegeKeyAdd(target PrivilegeKeyTarget, options ...CmdArg) (string, error) {
+	return s.PrivilegeKeyAddContext(context.Background(), target, options...)
+}
+
+// PrivilegeKeyAddContext creates a new privilege key on the selected server which
+// grants target and returns it.
+func (s *ServerMethods) PrivilegeKeyAddContext(ctx context.Context, target PrivilegeKeyTarget, options ...CmdArg) (string, error) {
+	if target == nil {
+		return "", errors.New("privilege key add: nil target")
+	}
+
 	t := struct {
 		Token string
 	}{}
-	options = append(options, NewArg("tokentype", ttype), NewArg("tokenid1", id1), NewArg("tokenid2", id2))
-	_, err := s.ExecCmd(NewCmd("privilegekeylist").WithArgs(options...).WithResponse(&t))
-	return t.Token, err
+	args := append([]CmdArg{target}, options...)
+	if _, err := s.ExecCmdContext(ctx, NewCmd("privilegekeyadd").WithArgs(args...).WithResponse(&t)); err != nil {
+		return "", err
+	}
+
+	return t.Token, nil
+}
+
+// PrivilegeKeyDelete deletes the privilege key token from the selected server.
+func (s *ServerMethods) PrivilegeKeyDelete(token string) error {
+	return s.PrivilegeKeyDeleteContext(context.Background(), token)
+}
+
+// PrivilegeKeyDeleteContext deletes the privilege key token from the selected server.
+func (s *ServerMethods) PrivilegeKeyDeleteContext(ctx context.Context, token string) error {
+	_, err := s.ExecCmdContext(ctx, NewCmd("privilegekeydelete").WithArgs(NewArg("token", token)))
+	return err
+}
+
+// PrivilegeKeyUse uses the privilege key token, granting its group to the
+// query client. The key is deleted once used.
+func (s *ServerMethods) PrivilegeKeyUse(token string) error {
+	return s.PrivilegeKeyUseContext(context.Background(), token)
+}
+
+// PrivilegeKeyUseContext uses the privilege key token, granting its group to the query client.
+func (s *ServerMethods) PrivilegeKeyUseContext(ctx context.Context, token string) error {
+	_, err := s.ExecCmdContext(ctx, NewCmd("privilegekeyuse").WithArgs(NewArg("token", token)))
+	return err
 }
 
 // OnlineClient represents a client online on a virtual server.
@@ -436,28 +583,14 @@ type OnlineClientInfo struct {
 
 // ClientList returns a list of online clients.
 func (s *ServerMethods) ClientList(options ...string) ([]*OnlineClient, error) {
-	var clients []*OnlineClient
-	if _, err := s.ExecCmd(NewCmd("clientlist").WithOptions(options...).WithResponse(&clients)); err != nil {
-		return nil, err
-	}
-	return clients, nil
+	return s.ClientListContext(context.Background(), options...)
 }
 
-// DBClient represents a client identity on a virtual server.
-type DBClient struct {
-	ID               int       `ms:"cldbid"`
-	UniqueIdentifier string    `ms:"client_unique_identifier"`
-	Nickname         string    `ms:"client_nickname"`
-	Created          time.Time `ms:"client_created"`
-	LastConnected    time.Time `ms:"client_lastconnected"`
-	Connections      int       `ms:"client_totalconnections"`
-}
-
-// ClientDBList returns a list of client identities known by the server.
-func (s *ServerMethods) ClientDBList() ([]*DBClient, error) {
-	var dbclients []*DBClient
-	if _, err := s.ExecCmd(NewCmd("clientdblist").WithResponse(&dbclients)); err != nil {
+// ClientListContext returns a list of online clients.
+func (s *ServerMethods) ClientListContext(ctx context.Context, options ...string) ([]*OnlineClient, error) {
+	var clients []*OnlineClient
+	if _, err := s.ExecCmdContext(ctx, NewCmd("clientlist").WithOptions(options...).WithResponse(&clients)); err != nil {
 		return nil, err
 	}
-	return dbclients, nil
+	return clients, nil
 }
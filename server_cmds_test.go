@@ -22,7 +22,7 @@ func TestCmdsServer(t *testing.T) {
 		assert.NoError(t, c.Close())
 	}()
 
-	testCmdsServer(t, c)
+	testCmdsServer(t, c, s)
 }
 
 func TestCmdsServerSSH(t *testing.T) {
@@ -40,11 +40,16 @@ func TestCmdsServerSSH(t *testing.T) {
 		assert.NoError(t, c.Close())
 	}()
 
-	testCmdsServer(t, c)
+	testCmdsServer(t, c, s)
 }
 
-func testCmdsServer(t *testing.T, c *Client) {
+func testCmdsServer(t *testing.T, c *Client, s *server) {
 	t.Helper()
+	lastCmd := func() string {
+		received := s.Received()
+		return received[len(received)-1]
+	}
+
 	list := func(t *testing.T) {
 		t.Helper()
 		servers, err := c.Server.List()
@@ -169,9 +174,10 @@ func testCmdsServer(t *testing.T, c *Client) {
 
 		expected := []*Group{
 			{
-				ID:   1,
-				Name: "Guest Server Query",
-				Type: 2,
+				ID:                1,
+				Name:              "Guest Server Query",
+				Type:              GroupTypeQuery,
+				MemberRemovePower: 25,
 			},
 			{
 				ID:                2,
@@ -198,19 +204,54 @@ func testCmdsServer(t *testing.T, c *Client) {
 			{
 				Token:   "zTfamFVhiMEzhTl49KrOVYaMilHPDQEBQOJFh6qX",
 				ID1:     17395,
-				Created: 1499948005,
+				Created: time.Unix(1499948005, 0),
 			},
 		}
 		assert.Equal(t, expected, keys)
+		assert.Equal(t, ServerGroupToken{GroupID: 17395}, keys[0].Target())
+
+		s.setResponse("privilegekeylist", errEmpty)
+		defer s.setResponse("privilegekeylist", commands["privilegekeylist"])
+
+		keys, err = c.Server.PrivilegeKeyList()
+		assert.NoError(t, err)
+		assert.Empty(t, keys)
 	}
 
 	privilegekeyadd := func(t *testing.T) {
 		t.Helper()
-		token, err := c.Server.PrivilegeKeyAdd(0, 17395, 0)
+		token, err := c.Server.PrivilegeKeyAdd(ServerGroupToken{GroupID: 17395})
 		if !assert.NoError(t, err) {
 			return
 		}
-		assert.Equal(t, "zTfamFVhiMEzhTl49KrOVYaMilHPDQEBQOJFh6qX", token)
+		assert.Equal(t, "zTfamFVhiMEzhTl49KrOVYaMilHPgQEBQOJFh6qX", token)
+		assert.Equal(t, "privilegekeyadd tokentype=0 tokenid1=17395 tokenid2=0", lastCmd())
+
+		_, err = c.Server.PrivilegeKeyAdd(
+			ChannelGroupToken{GroupID: 5, ChannelID: 2},
+			PrivilegeKeyDescription("mod key"),
+			PrivilegeKeyCustomSet{"forum_user": "dante", "forum_id": "123"},
+		)
+		assert.NoError(t, err)
+		assert.Equal(t, `privilegekeyadd tokentype=1 tokenid1=5 tokenid2=2 tokendescription=mod\skey `+
+			`tokencustomset=ident=forum_id\svalue=123\pident=forum_user\svalue=dante`, lastCmd())
+
+		n := len(s.Received())
+		_, err = c.Server.PrivilegeKeyAdd(nil)
+		assert.Error(t, err)
+		assert.Len(t, s.Received(), n)
+	}
+
+	privilegekeydelete := func(t *testing.T) {
+		t.Helper()
+		assert.NoError(t, c.Server.PrivilegeKeyDelete("zTfamFVhiMEzhTl49KrOVYaMilHPgQEBQOJFh6qX"))
+		assert.Equal(t, `privilegekeydelete token=zTfamFVhiMEzhTl49KrOVYaMilHPgQEBQOJFh6qX`, lastCmd())
+	}
+
+	privilegekeyuse := func(t *testing.T) {
+		t.Helper()
+		assert.NoError(t, c.Server.PrivilegeKeyUse("zTfamFVhiMEzhTl49KrOVYaMilHPgQEBQOJFh6qX"))
+		assert.Equal(t, `privilegekeyuse token=zTfamFVhiMEzhTl49KrOVYaMilHPgQEBQOJFh6qX`, lastCmd())
 	}
 
 	serverrequestconnectioninfo := func(t *testing.T) {
@@ -417,6 +458,8 @@ func testCmdsServer(t *testing.T, c *Client) {
 		{"grouplist", grouplist},
 		{"privilegekeylist", privilegekeylist},
 		{"privilegekeyadd", privilegekeyadd},
+		{"privilegekeydelete", privilegekeydelete},
+		{"privilegekeyuse", privilegekeyuse},
 		{"serverrequestconnectioninfo", serverrequestconnectioninfo},
 		{"instanceinfo", instanceinfo},
 		{"channellist", channellist},
@@ -0,0 +1,572 @@
+package ts3
+
+import (
+	"context"
+	"sort"
+	"sync"
+)
+
+// StateChange describes a change to a ServerState.
+type StateChange struct {
+	// Event is the event which caused the change.
+	Event Event
+
+	// Client is the client affected by the change, if any. For a client
+	// leaving it's the last known state of the client.
+	Client *OnlineClient
+
+	// Channel is the channel affected by the change, if any. For a deleted
+	// channel it's the last known state of the channel.
+	Channel *Channel
+}
+
+// EventType implements Event.
+func (c StateChange) EventType() string {
+	return c.Event.EventType()
+}
+
+// ServerState is a local copy of the clients, channels and server groups of
+// the selected server which is kept up to date using notifications instead
+// of polling.
+//
+// The values returned by ServerState are shared and must not be modified.
+type ServerState struct {
+	c       *Client
+	sub     *Subscription
+	changes *dispatcher
+
+	// Below here is protected by mtx.
+	mtx       sync.RWMutex
+	recording bool
+	pending   []Event
+	clients   map[int]*OnlineClient
+	channels  map[int]*Channel
+	groups    []*Group
+}
+
+// NewServerState registers for server and channel events, then returns a
+// ServerState bootstrapped from ClientList, ChannelList and GroupList.
+//
+// Registering for channel events replaces any previous RegisterChannel call.
+// If the connection is restored after being lost, Refresh should be called as
+// any changes made while disconnected are not seen.
+func NewServerState(c *Client) (*ServerState, error) {
+	return NewServerStateContext(context.Background(), c)
+}
+
+// NewServerStateContext registers for server and channel events, then returns a
+// ServerState bootstrapped from ClientList, ChannelList and GroupList.
+func NewServerStateContext(ctx context.Context, c *Client) (*ServerState, error) {
+	st := &ServerState{
+		c:       c,
+		changes: newDispatcher(1),
+	}
+
+	// Subscribe first so no events are missed while bootstrapping.
+	st.sub = c.Subscribe(nil, st.handle)
+
+	if err := c.RegisterContext(ctx, ServerEvents); err != nil {
+		st.Close()
+		return nil, err
+	}
+
+	if err := c.RegisterChannelContext(ctx, 0); err != nil {
+		st.Close()
+		return nil, err
+	}
+
+	if err := st.RefreshContext(ctx); err != nil {
+		st.Close()
+		return nil, err
+	}
+
+	return st, nil
+}
+
+// Refresh reloads the state from the server.
+func (st *ServerState) Refresh() error {
+	return st.RefreshContext(context.Background())
+}
+
+// RefreshContext reloads the state from the server.
+func (st *ServerState) RefreshContext(ctx context.Context) error {
+	st.mtx.Lock()
+	st.recording = true
+	st.mtx.Unlock()
+
+	clients, channels, groups, err := st.load(ctx)
+
+	st.mtx.Lock()
+	defer st.mtx.Unlock()
+
+	if err != nil {
+		st.recording = false
+		st.pending = nil
+		return err
+	}
+
+	st.clients = make(map[int]*OnlineClient, len(clients))
+	for _, cl := range clients {
+		st.clients[cl.ID] = cl
+	}
+
+	st.channels = make(map[int]*Channel, len(channels))
+	for _, ch := range channels {
+		st.channels[ch.ID] = ch
+	}
+
+	st.groups = groups
+
+	// Events received while loading may already be reflected in the lists,
+	// applying them again is harmless. Changes were already reported when
+	// the events were first applied, if the state had been loaded.
+	for _, e := range st.pending {
+		for _, e := range splitEvent(e) {
+			st.apply(e)
+		}
+	}
+	st.pending = nil
+	st.recording = false
+
+	return nil
+}
+
+// load returns the clients, channels and groups from the server.
+func (st *ServerState) load(ctx context.Context) ([]*OnlineClient, []*Channel, []*Group, error) {
+	clients, err := st.c.Server.ClientListContext(ctx, ClientListFull)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	channels, err := st.c.Server.ChannelListContext(ctx, ChannelListFull)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	groups, err := st.c.Server.GroupListContext(ctx)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	return clients, channels, groups, nil
+}
+
+// Close stops the state from being updated and stops calling change handlers.
+// It doesn't unregister from events.
+func (st *ServerState) Close() {
+	st.sub.Unsubscribe()
+	st.changes.close()
+}
+
+// OnChange registers handler to be called after each change to the state.
+// Handlers are called in order, one at a time.
+func (st *ServerState) OnChange(handler func(StateChange)) *Subscription {
+	return st.changes.add(nil, func(e Event) {
+		handler(e.(StateChange))
+	})
+}
+
+// Clients returns the online clients ordered by ID.
+func (st *ServerState) Clients() []*OnlineClient {
+	st.mtx.RLock()
+	defer st.mtx.RUnlock()
+
+	clients := make([]*OnlineClient, 0, len(st.clients))
+	for _, cl := range st.clients {
+		clients = append(clients, cl)
+	}
+	sort.Slice(clients, func(i, j int) bool {
+		return clients[i].ID < clients[j].ID
+	})
+
+	return clients
+}
+
+// Client returns the online client with the given id or nil if not present.
+func (st *ServerState) Client(id int) *OnlineClient {
+	st.mtx.RLock()
+	defer st.mtx.RUnlock()
+
+	return st.clients[id]
+}
+
+// Channels returns the channels ordered by ID.
+func (st *ServerState) Channels() []*Channel {
+	st.mtx.RLock()
+	defer st.mtx.RUnlock()
+
+	return st.channelList()
+}
+
+// channelList returns the channels ordered by ID.
+// st.mtx must be held.
+func (st *ServerState) channelList() []*Channel {
+	channels := make([]*Channel, 0, len(st.channels))
+	for _, ch := range st.channels {
+		channels = append(channels, ch)
+	}
+	sort.Slice(channels, func(i, j int) bool {
+		return channels[i].ID < channels[j].ID
+	})
+
+	return channels
+}
+
+// Channel returns the channel with the given id or nil if not present.
+func (st *ServerState) Channel(id int) *Channel {
+	st.mtx.RLock()
+	defer st.mtx.RUnlock()
+
+	return st.channels[id]
+}
+
+// Groups returns the server groups as of the last Refresh.
+func (st *ServerState) Groups() []*Group {
+	st.mtx.RLock()
+	defer st.mtx.RUnlock()
+
+	return append([]*Group(nil), st.groups...)
+}
+
+// Tree returns a ChannelTree of the current channels and clients.
+func (st *ServerState) Tree() *ChannelTree {
+	st.mtx.RLock()
+	defer st.mtx.RUnlock()
+
+	clients := make([]*OnlineClient, 0, len(st.clients))
+	for _, cl := range st.clients {
+		clients = append(clients, cl)
+	}
+	sort.Slice(clients, func(i, j int) bool {
+		return clients[i].ID < clients[j].ID
+	})
+
+	return NewChannelTree(st.channelList(), clients)
+}
+
+// handle processes an event from the client.
+func (st *ServerState) handle(e Event) {
+	st.mtx.Lock()
+	defer st.mtx.Unlock()
+
+	if st.recording {
+		st.pending = append(st.pending, e)
+	}
+
+	if st.clients == nil {
+		// Not loaded yet.
+		return
+	}
+
+	for _, e := range splitEvent(e) {
+		if c, ok := st.apply(e); ok {
+			st.changes.dispatch(c)
+		}
+	}
+}
+
+// splitEvent returns the events affecting a single client which e
+// consists of, so each results in its own StateChange.
+func splitEvent(e Event) []Event {
+	m, ok := e.(*ClientMoved)
+	if !ok || len(m.ClientIDs) <= 1 {
+		return []Event{e}
+	}
+
+	events := make([]Event, len(m.ClientIDs))
+	for i, id := range m.ClientIDs {
+		moved := *m
+		moved.ClientID = id
+		moved.ClientIDs = []int{id}
+		events[i] = &moved
+	}
+
+	return events
+}
+
+// apply updates the state for e, returning the change and true if
+// the state was changed.
+// st.mtx must be held.
+//
+// Stored values are shared with callers so they are replaced rather
+// than modified.
+func (st *ServerState) apply(e Event) (StateChange, bool) {
+	c := StateChange{Event: e}
+	switch e := e.(type) {
+	case *ClientEnterView:
+		c.Client = clientFromEnterView(*e)
+		if old, ok := st.clients[e.ClientID]; ok {
+			st.addChannelClients(old.ChannelID, -1)
+		}
+		st.clients[e.ClientID] = c.Client
+		st.addChannelClients(e.ToChannelID, 1)
+	case *ClientLeftView:
+		cl, ok := st.clients[e.ClientID]
+		if !ok {
+			return c, false
+		}
+		c.Client = cl
+		delete(st.clients, e.ClientID)
+		st.addChannelClients(cl.ChannelID, -1)
+	case *ClientMoved:
+		cl, ok := st.clients[e.ClientID]
+		if !ok {
+			return c, false
+		}
+		moved := *cl
+		moved.ChannelID = e.ToChannelID
+		c.Client = &moved
+		st.clients[e.ClientID] = c.Client
+		st.addChannelClients(cl.ChannelID, -1)
+		st.addChannelClients(e.ToChannelID, 1)
+	case *ChannelCreated:
+		c.Channel = channelFromCreated(*e)
+		st.channels[e.ChannelID] = c.Channel
+		st.linkChannel(c.Channel)
+	case *ChannelEdited:
+		ch, ok := st.channels[e.ChannelID]
+		if !ok {
+			return c, false
+		}
+		if e.Order != nil && *e.Order != ch.ChannelOrder {
+			st.unlinkChannel(ch)
+		}
+		c.Channel = editChannel(st.channels[e.ChannelID], e)
+		st.channels[e.ChannelID] = c.Channel
+		if e.Order != nil && *e.Order != ch.ChannelOrder {
+			st.linkChannel(c.Channel)
+		}
+	case *ChannelDeleted:
+		ch, ok := st.channels[e.ChannelID]
+		if !ok {
+			return c, false
+		}
+		c.Channel = ch
+		st.deleteChannel(ch)
+	case *ChannelMoved:
+		if _, ok := st.channels[e.ChannelID]; !ok {
+			return c, false
+		}
+		st.unlinkChannel(st.channels[e.ChannelID])
+		moved := *st.channels[e.ChannelID]
+		moved.ParentID = e.ParentID
+		moved.ChannelOrder = e.Order
+		c.Channel = &moved
+		st.channels[e.ChannelID] = c.Channel
+		st.linkChannel(c.Channel)
+	default:
+		return c, false
+	}
+
+	return c, true
+}
+
+// addChannelClients adds n to the total clients of channel id.
+func (st *ServerState) addChannelClients(id, n int) {
+	if ch, ok := st.channels[id]; ok {
+		updated := *ch
+		updated.TotalClients += n
+		st.channels[id] = &updated
+	}
+}
+
+// setChannelOrder sets the order of sibling channel id.
+func (st *ServerState) setChannelOrder(id, order int) {
+	if ch, ok := st.channels[id]; ok {
+		updated := *ch
+		updated.ChannelOrder = order
+		st.channels[id] = &updated
+	}
+}
+
+// sibling returns the ID of the channel ordered after the channel
+// with the given id in parent, or 0 if there is none.
+func (st *ServerState) sibling(parent, id, order int) int {
+	for _, ch := range st.channels {
+		if ch.ParentID == parent && ch.ChannelOrder == order && ch.ID != id {
+			return ch.ID
+		}
+	}
+	return 0
+}
+
+// linkChannel inserts ch into its sibling order, moving the channel
+// previously after ch.ChannelOrder to be after ch.
+func (st *ServerState) linkChannel(ch *Channel) {
+	if id := st.sibling(ch.ParentID, ch.ID, ch.ChannelOrder); id != 0 {
+		st.setChannelOrder(id, ch.ID)
+	}
+}
+
+// unlinkChannel removes ch from its sibling order, moving the channel
+// after ch to take its place.
+func (st *ServerState) unlinkChannel(ch *Channel) {
+	if id := st.sibling(ch.ParentID, ch.ID, ch.ID); id != 0 {
+		st.setChannelOrder(id, ch.ChannelOrder)
+	}
+}
+
+// deleteChannel removes ch and its sub channels.
+func (st *ServerState) deleteChannel(ch *Channel) {
+	for _, sub := range st.channels {
+		if sub.ParentID == ch.ID {
+			st.deleteChannel(sub)
+		}
+	}
+
+	st.unlinkChannel(ch)
+	delete(st.channels, ch.ID)
+}
+
+// clientFromEnterView returns an OnlineClient for e.
+func clientFromEnterView(e ClientEnterView) *OnlineClient {
+	servergroups := append([]int(nil), e.ServerGroups...)
+	return &OnlineClient{
+		ID:          e.ClientID,
+		ChannelID:   e.ToChannelID,
+		DatabaseID:  e.DatabaseID,
+		Nickname:    e.Nickname,
+		Type:        e.Type,
+		Away:        e.Away,
+		AwayMessage: e.AwayMessage,
+		OnlineClientExt: &OnlineClientExt{
+			UniqueIdentifier: &e.UniqueIdentifier,
+			OnlineClientVoice: &OnlineClientVoice{
+				InputMuted:         &e.InputMuted,
+				OutputMuted:        &e.OutputMuted,
+				InputHardware:      &e.InputHardware,
+				OutputHardware:     &e.OutputHardware,
+				TalkPower:          &e.TalkPower,
+				IsTalker:           &e.IsTalker,
+				IsPrioritySpeaker:  &e.IsPrioritySpeaker,
+				IsRecording:        &e.IsRecording,
+				IsChannelCommander: &e.IsChannelCommander,
+			},
+			OnlineClientGroups: &OnlineClientGroups{
+				ChannelGroupID:                 &e.ChannelGroupID,
+				ChannelGroupInheritedChannelID: &e.ChannelGroupInheritedChannelID,
+				ServerGroups:                   &servergroups,
+			},
+			Country: &e.Country,
+			Badges:  &e.Badges,
+			IconID:  &e.IconID,
+		},
+	}
+}
+
+// channelFromCreated returns a Channel for e.
+func channelFromCreated(e ChannelCreated) *Channel {
+	codec := ChannelCodec(e.Codec)
+	totalFamily := 0
+	return &Channel{
+		ID:           e.ChannelID,
+		ParentID:     e.ParentID,
+		ChannelOrder: e.Order,
+		ChannelName:  e.Name,
+		ChannelExt: &ChannelExt{
+			Topic: &e.Topic,
+			ChannelFlags: &ChannelFlags{
+				FlagDefault:       &e.FlagDefault,
+				FlagPassword:      &e.FlagPassword,
+				FlagPermanent:     &e.FlagPermanent,
+				FlagSemiPermanent: &e.FlagSemiPermanent,
+			},
+			ChannelVoice: &ChannelVoice{
+				Codec:           &codec,
+				CodecQuality:    &e.CodecQuality,
+				NeededTalkPower: &e.NeededTalkPower,
+			},
+			ChannelLimits: &ChannelLimits{
+				TotalClientsFamily: &totalFamily,
+				MaxClients:         &e.MaxClients,
+				MaxFamilyClients:   &e.MaxFamilyClients,
+			},
+			IconID: &e.IconID,
+		},
+	}
+}
+
+// editChannel returns a copy of ch with the changes from e applied.
+func editChannel(ch *Channel, e *ChannelEdited) *Channel {
+	edited := *ch
+	if e.Name != nil {
+		edited.ChannelName = *e.Name
+	}
+	if e.Order != nil {
+		edited.ChannelOrder = *e.Order
+	}
+
+	if e.Topic == nil && e.FlagDefault == nil && e.FlagPassword == nil &&
+		e.FlagPermanent == nil && e.FlagSemiPermanent == nil &&
+		e.Codec == nil && e.CodecQuality == nil && e.NeededTalkPower == nil &&
+		e.MaxClients == nil && e.MaxFamilyClients == nil && e.IconID == nil {
+		return &edited
+	}
+
+	ext := ChannelExt{}
+	if ch.ChannelExt != nil {
+		ext = *ch.ChannelExt
+	}
+	edited.ChannelExt = &ext
+
+	if e.Topic != nil {
+		ext.Topic = e.Topic
+	}
+	if e.IconID != nil {
+		ext.IconID = e.IconID
+	}
+
+	if e.FlagDefault != nil || e.FlagPassword != nil || e.FlagPermanent != nil || e.FlagSemiPermanent != nil {
+		flags := ChannelFlags{}
+		if ext.ChannelFlags != nil {
+			flags = *ext.ChannelFlags
+		}
+		ext.ChannelFlags = &flags
+		if e.FlagDefault != nil {
+			flags.FlagDefault = e.FlagDefault
+		}
+		if e.FlagPassword != nil {
+			flags.FlagPassword = e.FlagPassword
+		}
+		if e.FlagPermanent != nil {
+			flags.FlagPermanent = e.FlagPermanent
+		}
+		if e.FlagSemiPermanent != nil {
+			flags.FlagSemiPermanent = e.FlagSemiPermanent
+		}
+	}
+
+	if e.Codec != nil || e.CodecQuality != nil || e.NeededTalkPower != nil {
+		voice := ChannelVoice{}
+		if ext.ChannelVoice != nil {
+			voice = *ext.ChannelVoice
+		}
+		ext.ChannelVoice = &voice
+		if e.Codec != nil {
+			codec := ChannelCodec(*e.Codec)
+			voice.Codec = &codec
+		}
+		if e.CodecQuality != nil {
+			voice.CodecQuality = e.CodecQuality
+		}
+		if e.NeededTalkPower != nil {
+			voice.NeededTalkPower = e.NeededTalkPower
+		}
+	}
+
+	if e.MaxClients != nil || e.MaxFamilyClients != nil {
+		limits := ChannelLimits{}
+		if ext.ChannelLimits != nil {
+			limits = *ext.ChannelLimits
+		}
+		ext.ChannelLimits = &limits
+		if e.MaxClients != nil {
+			limits.MaxClients = e.MaxClients
+		}
+		if e.MaxFamilyClients != nil {
+			limits.MaxFamilyClients = e.MaxFamilyClients
+		}
+	}
+
+	return &edited
+}
@@ -0,0 +1,217 @@
+package ts3
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestServerState(t *testing.T) {
+	s := newServer(t)
+	defer func() {
+		assert.NoError(t, s.Close())
+	}()
+
+	c, err := NewClient(s.Addr, Timeout(time.Second))
+	require.NoError(t, err)
+
+	defer func() {
+		assert.NoError(t, c.Close())
+	}()
+
+	st, err := NewServerState(c)
+	require.NoError(t, err)
+	defer st.Close()
+
+	received := s.Received()
+	assert.Equal(t, []string{
+		"servernotifyregister event=server",
+		"servernotifyregister event=channel id=0",
+		"clientlist -uid -away -voice -times -groups -info -icon -country -ip -badges",
+		"channellist -topic -flags -voice -limits -icon -secondsempty",
+		"servergrouplist",
+	}, received[len(received)-5:])
+
+	require.Len(t, st.Clients(), 1)
+	assert.Equal(t, "bdeb1337", st.Client(42087).Nickname)
+	require.Len(t, st.Channels(), 1)
+	assert.Equal(t, 1, st.Channel(499).TotalClients)
+	assert.Len(t, st.Groups(), 2)
+
+	changes := make(chan StateChange, 1)
+	st.OnChange(func(c StateChange) {
+		changes <- c
+	})
+
+	st.handle(&ClientMoved{ClientID: 42087, ToChannelID: 499})
+
+	select {
+	case c := <-changes:
+		assert.Equal(t, EventClientMoved, c.EventType())
+		require.NotNil(t, c.Client)
+		assert.Equal(t, 499, c.Client.ChannelID)
+	case <-time.After(time.Second):
+		t.Fatal("no change")
+	}
+
+	assert.Equal(t, 499, st.Client(42087).ChannelID)
+	assert.Equal(t, 2, st.Channel(499).TotalClients)
+	assert.Equal(t, []string{"Default Channel", "  * bdeb1337", ""}, strings.Split(st.Tree().String(), "\n"))
+
+	// Unknown clients don't cause a change.
+	st.handle(&ClientMoved{ClientID: 1, ToChannelID: 499})
+	select {
+	case c := <-changes:
+		t.Fatalf("unexpected change %#v", c)
+	case <-time.After(time.Millisecond * 50):
+	}
+}
+
+func newTestServerState(channels ...*Channel) *ServerState {
+	st := &ServerState{
+		changes:  newDispatcher(1),
+		clients:  make(map[int]*OnlineClient),
+		channels: make(map[int]*Channel),
+	}
+	for _, ch := range channels {
+		st.channels[ch.ID] = ch
+	}
+	return st
+}
+
+// channelOrder returns the IDs of the children of parent in display order.
+func channelOrder(st *ServerState, parent int) []int {
+	var nodes []*ChannelNode
+	tree := st.Tree()
+	if parent == 0 {
+		nodes = tree.Roots
+	} else {
+		nodes = tree.Find(parent).Children
+	}
+
+	ids := make([]int, len(nodes))
+	for i, n := range nodes {
+		ids[i] = n.Channel.ID
+	}
+	return ids
+}
+
+func TestServerStateChannels(t *testing.T) {
+	st := newTestServerState(
+		&Channel{ID: 1, ChannelName: "Lobby"},
+		&Channel{ID: 2, ChannelOrder: 1, ChannelName: "Games"},
+		&Channel{ID: 3, ChannelOrder: 2, ChannelName: "AFK"},
+	)
+
+	_, ok := st.apply(&ChannelCreated{ChannelID: 4, Order: 1, Name: "New"})
+	require.True(t, ok)
+	assert.Equal(t, []int{1, 4, 2, 3}, channelOrder(st, 0))
+	assert.Equal(t, "New", st.Channel(4).ChannelName)
+
+	// Replaying an event is harmless.
+	_, ok = st.apply(&ChannelCreated{ChannelID: 4, Order: 1, Name: "New"})
+	require.True(t, ok)
+	assert.Equal(t, []int{1, 4, 2, 3}, channelOrder(st, 0))
+
+	name := "Renamed"
+	order := 3
+	topic := "topic"
+	maxClients := 5
+	c, ok := st.apply(&ChannelEdited{ChannelID: 1, Name: &name, Order: &order, Topic: &topic, MaxClients: &maxClients})
+	require.True(t, ok)
+	assert.Equal(t, "Renamed", c.Channel.ChannelName)
+	assert.Equal(t, &topic, c.Channel.Topic)
+	assert.Equal(t, &maxClients, c.Channel.MaxClients)
+	assert.Equal(t, []int{4, 2, 3, 1}, channelOrder(st, 0))
+
+	_, ok = st.apply(&ChannelMoved{ChannelID: 3, ParentID: 4, Order: 0})
+	require.True(t, ok)
+	assert.Equal(t, []int{4, 2, 1}, channelOrder(st, 0))
+	assert.Equal(t, []int{3}, channelOrder(st, 4))
+
+	c, ok = st.apply(&ChannelDeleted{ChannelID: 4})
+	require.True(t, ok)
+	assert.Equal(t, 4, c.Channel.ID)
+	assert.Equal(t, []int{2, 1}, channelOrder(st, 0))
+	assert.Nil(t, st.Channel(3))
+
+	_, ok = st.apply(&ChannelDeleted{ChannelID: 4})
+	assert.False(t, ok)
+}
+
+func TestServerStateClients(t *testing.T) {
+	st := newTestServerState(
+		&Channel{ID: 1, ChannelName: "Lobby"},
+		&Channel{ID: 2, ChannelOrder: 1, ChannelName: "Games"},
+	)
+
+	c, ok := st.apply(&ClientEnterView{ClientID: 10, ToChannelID: 1, Nickname: "alice", ServerGroups: []int{6, 8}})
+	require.True(t, ok)
+	assert.Equal(t, "alice", c.Client.Nickname)
+	assert.Equal(t, &[]int{6, 8}, c.Client.ServerGroups)
+	assert.Equal(t, 1, st.Channel(1).TotalClients)
+
+	before := st.Channel(1)
+	_, ok = st.apply(&ClientMoved{ClientID: 10, ToChannelID: 2})
+	require.True(t, ok)
+	assert.Equal(t, 2, st.Client(10).ChannelID)
+	assert.Equal(t, 0, st.Channel(1).TotalClients)
+	assert.Equal(t, 1, st.Channel(2).TotalClients)
+	assert.Equal(t, 1, before.TotalClients, "previous values must not be modified")
+
+	c, ok = st.apply(&ClientLeftView{ClientID: 10})
+	require.True(t, ok)
+	assert.Equal(t, 2, c.Client.ChannelID)
+	assert.Nil(t, st.Client(10))
+	assert.Equal(t, 0, st.Channel(2).TotalClients)
+
+	_, ok = st.apply(&ClientLeftView{ClientID: 10})
+	assert.False(t, ok)
+
+	_, ok = st.apply(&TextMessage{})
+	assert.False(t, ok)
+}
+
+func TestServerStateClientsMovedTogether(t *testing.T) {
+	st := newTestServerState(
+		&Channel{ID: 1, ChannelName: "Lobby"},
+		&Channel{ID: 2, ChannelOrder: 1, ChannelName: "Games"},
+	)
+	for _, id := range []int{10, 11, 12} {
+		_, ok := st.apply(&ClientEnterView{ClientID: id, ToChannelID: 1})
+		require.True(t, ok)
+	}
+
+	changes := make(chan StateChange, 3)
+	st.OnChange(func(c StateChange) {
+		changes <- c
+	})
+
+	n, err := decodeNotification(`notifyclientmoved ctid=2 reasonid=1 invokerid=1 invokername=admin invokeruid=xyz= clid=10|clid=12`)
+	require.NoError(t, err)
+	e, err := n.Event()
+	require.NoError(t, err)
+	st.handle(e)
+
+	moved := make(map[int]bool)
+	for i := 0; i < 2; i++ {
+		select {
+		case c := <-changes:
+			require.NotNil(t, c.Client)
+			assert.Equal(t, 2, c.Client.ChannelID)
+			moved[c.Client.ID] = true
+		case <-time.After(time.Second):
+			t.Fatal("no change")
+		}
+	}
+	assert.Equal(t, map[int]bool{10: true, 12: true}, moved)
+
+	assert.Equal(t, 2, st.Client(10).ChannelID)
+	assert.Equal(t, 1, st.Client(11).ChannelID)
+	assert.Equal(t, 2, st.Client(12).ChannelID)
+	assert.Equal(t, 1, st.Channel(1).TotalClients)
+	assert.Equal(t, 2, st.Channel(2).TotalClients)
+}
@@ -0,0 +1,221 @@
+package ts3
+
+import (
+	"context"
+)
+
+// GroupType is the database type of a group.
+type GroupType int
+
+const (
+	// GroupTypeTemplate is a template group used for new virtual servers.
+	GroupTypeTemplate GroupType = iota
+	// GroupTypeRegular is a regular group used by clients.
+	GroupTypeRegular
+	// GroupTypeQuery is a group used by ServerQuery clients.
+	GroupTypeQuery
+)
+
+// Group represents a virtual server group.
+type Group struct {
+	ID                int `ms:"sgid"`
+	Name              string
+	Type              GroupType
+	IconID            int
+	Saved             bool `ms:"savedb"`
+	SortID            int
+	NameMode          int
+	ModifyPower       int `ms:"n_modifyp"`
+	MemberAddPower    int `ms:"n_member_addp"`
+	MemberRemovePower int `ms:"n_member_removep"`
+}
+
+// GroupList returns a list of available groups for the selected server.
+func (s *ServerMethods) GroupList() ([]*Group, error) {
+	return s.GroupListContext(context.Background())
+}
+
+// GroupListContext returns a list of available groups for the selected server.
+func (s *ServerMethods) GroupListContext(ctx context.Context) ([]*Group, error) {
+	var groups []*Group
+	if _, err := s.ExecCmdContext(ctx, NewCmd("servergrouplist").WithResponse(&groups)); err != nil {
+		return nil, err
+	}
+
+	return groups, nil
+}
+
+// ServerGroupAdd creates a new server group of type typ and returns its ID.
+func (s *ServerMethods) ServerGroupAdd(name string, typ GroupType) (int, error) {
+	return s.ServerGroupAddContext(context.Background(), name, typ)
+}
+
+// ServerGroupAddContext creates a new server group of type typ and returns its ID.
+func (s *ServerMethods) ServerGroupAddContext(ctx context.Context, name string, typ GroupType) (int, error) {
+	r := struct {
+		ID int `ms:"sgid"`
+	}{}
+	if _, err := s.ExecCmdContext(ctx, NewCmd("servergroupadd").WithArgs(
+		NewArg("name", name),
+		NewArg("type", int(typ)),
+	).WithResponse(&r)); err != nil {
+		return 0, err
+	}
+
+	return r.ID, nil
+}
+
+// ServerGroupDel deletes the server group specified by id.
+// If force is true the group is deleted even if it has members.
+func (s *ServerMethods) ServerGroupDel(id int, force bool) error {
+	return s.ServerGroupDelContext(context.Background(), id, force)
+}
+
+// ServerGroupDelContext deletes the server group specified by id.
+func (s *ServerMethods) ServerGroupDelContext(ctx context.Context, id int, force bool) error {
+	_, err := s.ExecCmdContext(ctx, NewCmd("servergroupdel").WithArgs(
+		NewArg("sgid", id),
+		NewArg("force", force),
+	))
+	return err
+}
+
+// ServerGroupCopy copies the server group sourceID, including its permissions,
+// to targetID. If targetID is 0 a new group called name of type typ is created
+// and its ID returned, otherwise targetID is overwritten and returned.
+func (s *ServerMethods) ServerGroupCopy(sourceID, targetID int, name string, typ GroupType) (int, error) {
+	return s.ServerGroupCopyContext(context.Background(), sourceID, targetID, name, typ)
+}
+
+// ServerGroupCopyContext copies the server group sourceID, including its permissions,
+// to targetID.
+func (s *ServerMethods) ServerGroupCopyContext(ctx context.Context, sourceID, targetID int, name string, typ GroupType) (int, error) {
+	cmd := NewCmd("servergroupcopy").WithArgs(
+		NewArg("ssgid", sourceID),
+		NewArg("tsgid", targetID),
+		NewArg("name", name),
+		NewArg("type", int(typ)),
+	)
+
+	// The server only returns the ID when a new group is created.
+	if targetID != 0 {
+		if _, err := s.ExecCmdContext(ctx, cmd); err != nil {
+			return 0, err
+		}
+		return targetID, nil
+	}
+
+	r := struct {
+		ID int `ms:"sgid"`
+	}{}
+	if _, err := s.ExecCmdContext(ctx, cmd.WithResponse(&r)); err != nil {
+		return 0, err
+	}
+
+	return r.ID, nil
+}
+
+// ServerGroupRename changes the name of the server group specified by id.
+func (s *ServerMethods) ServerGroupRename(id int, name string) error {
+	return s.ServerGroupRenameContext(context.Background(), id, name)
+}
+
+// ServerGroupRenameContext changes the name of the server group specified by id.
+func (s *ServerMethods) ServerGroupRenameContext(ctx context.Context, id int, name string) error {
+	_, err := s.ExecCmdContext(ctx, NewCmd("servergrouprename").WithArgs(
+		NewArg("sgid", id),
+		NewArg("name", name),
+	))
+	return err
+}
+
+// ServerGroupAddClient adds the clients specified by their database IDs to
+// the server group id.
+func (s *ServerMethods) ServerGroupAddClient(id int, dbIDs ...int) error {
+	return s.ServerGroupAddClientContext(context.Background(), id, dbIDs...)
+}
+
+// ServerGroupAddClientContext adds the clients specified by their database IDs to
+// the server group id.
+func (s *ServerMethods) ServerGroupAddClientContext(ctx context.Context, id int, dbIDs ...int) error {
+	_, err := s.ExecCmdContext(ctx, NewCmd("servergroupaddclient").WithArgs(
+		NewArg("sgid", id),
+		idGroup("cldbid", dbIDs),
+	))
+	return err
+}
+
+// ServerGroupDelClient removes the clients specified by their database IDs
+// from the server group id.
+func (s *ServerMethods) ServerGroupDelClient(id int, dbIDs ...int) error {
+	return s.ServerGroupDelClientContext(context.Background(), id, dbIDs...)
+}
+
+// ServerGroupDelClientContext removes the clients specified by their database IDs
+// from the server group id.
+func (s *ServerMethods) ServerGroupDelClientContext(ctx context.Context, id int, dbIDs ...int) error {
+	_, err := s.ExecCmdContext(ctx, NewCmd("servergroupdelclient").WithArgs(
+		NewArg("sgid", id),
+		idGroup("cldbid", dbIDs),
+	))
+	return err
+}
+
+// ServerGroupMember represents a member of a server group.
+type ServerGroupMember struct {
+	DatabaseID       int    `ms:"cldbid"`
+	Nickname         string `ms:"client_nickname"`          // Only populated if names is passed to ServerGroupClientList.
+	UniqueIdentifier string `ms:"client_unique_identifier"` // Only populated if names is passed to ServerGroupClientList.
+}
+
+// ServerGroupClientList returns the members of the server group id, which
+// is empty if the group has no members.
+// If names is true the nickname and unique identifier of each member is
+// also returned.
+func (s *ServerMethods) ServerGroupClientList(id int, names bool) ([]*ServerGroupMember, error) {
+	return s.ServerGroupClientListContext(context.Background(), id, names)
+}
+
+// ServerGroupClientListContext returns the members of the server group id.
+func (s *ServerMethods) ServerGroupClientListContext(ctx context.Context, id int, names bool) ([]*ServerGroupMember, error) {
+	cmd := NewCmd("servergroupclientlist").WithArgs(NewArg("sgid", id))
+	if names {
+		cmd.WithOptions("-names")
+	}
+
+	var members []*ServerGroupMember
+	if _, err := s.ExecCmdContext(ctx, cmd.WithResponse(&members)); err != nil {
+		if isEmptyResult(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return members, nil
+}
+
+// ClientServerGroup represents a server group a client is a member of.
+type ClientServerGroup struct {
+	ID         int    `ms:"sgid"`
+	Name       string `ms:"name"`
+	DatabaseID int    `ms:"cldbid"`
+}
+
+// ServerGroupsByClientID returns the server groups the client specified by
+// its database ID is a member of.
+func (s *ServerMethods) ServerGroupsByClientID(dbID int) ([]*ClientServerGroup, error) {
+	return s.ServerGroupsByClientIDContext(context.Background(), dbID)
+}
+
+// ServerGroupsByClientIDContext returns the server groups the client specified by
+// its database ID is a member of.
+func (s *ServerMethods) ServerGroupsByClientIDContext(ctx context.Context, dbID int) ([]*ClientServerGroup, error) {
+	var groups []*ClientServerGroup
+	if _, err := s.ExecCmdContext(ctx, NewCmd("servergroupsbyclientid").WithArgs(
+		NewArg("cldbid", dbID),
+	).WithResponse(&groups)); err != nil {
+		return nil, err
+	}
+
+	return groups, nil
+}
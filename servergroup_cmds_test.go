@@ -0,0 +1,153 @@
+package ts3
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCmdsServerGroup(t *testing.T) {
+	s := newServer(t)
+	defer func() {
+		assert.NoError(t, s.Close())
+	}()
+
+	c, err := NewClient(s.Addr, Timeout(time.Second*2))
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	defer func() {
+		assert.NoError(t, c.Close())
+	}()
+
+	testCmdsServerGroup(t, c, s)
+}
+
+func TestCmdsServerGroupSSH(t *testing.T) {
+	s := newServer(t, useSSH())
+	defer func() {
+		assert.NoError(t, s.Close())
+	}()
+
+	c, err := NewClient(s.Addr, Timeout(time.Second*2), SSH(sshClientTestConfig))
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	defer func() {
+		assert.NoError(t, c.Close())
+	}()
+
+	testCmdsServerGroup(t, c, s)
+}
+
+func testCmdsServerGroup(t *testing.T, c *Client, s *server) {
+	t.Helper()
+	lastCmd := func() string {
+		received := s.Received()
+		return received[len(received)-1]
+	}
+
+	servergroupadd := func(t *testing.T) {
+		t.Helper()
+		id, err := c.Server.ServerGroupAdd("Moderators", GroupTypeRegular)
+		if !assert.NoError(t, err) {
+			return
+		}
+		assert.Equal(t, 10, id)
+		assert.Equal(t, `servergroupadd name=Moderators type=1`, lastCmd())
+	}
+
+	servergroupdel := func(t *testing.T) {
+		t.Helper()
+		assert.NoError(t, c.Server.ServerGroupDel(10, true))
+		assert.Equal(t, `servergroupdel sgid=10 force=1`, lastCmd())
+	}
+
+	servergroupcopy := func(t *testing.T) {
+		t.Helper()
+		id, err := c.Server.ServerGroupCopy(2, 0, "Admin Copy", GroupTypeRegular)
+		if !assert.NoError(t, err) {
+			return
+		}
+		assert.Equal(t, 11, id)
+		assert.Equal(t, `servergroupcopy ssgid=2 tsgid=0 name=Admin\sCopy type=1`, lastCmd())
+
+		id, err = c.Server.ServerGroupCopy(2, 12, "Admin Copy", GroupTypeRegular)
+		if !assert.NoError(t, err) {
+			return
+		}
+		assert.Equal(t, 12, id)
+		assert.Equal(t, `servergroupcopy ssgid=2 tsgid=12 name=Admin\sCopy type=1`, lastCmd())
+	}
+
+	servergrouprename := func(t *testing.T) {
+		t.Helper()
+		assert.NoError(t, c.Server.ServerGroupRename(10, "Mods"))
+		assert.Equal(t, `servergrouprename sgid=10 name=Mods`, lastCmd())
+	}
+
+	servergroupaddclient := func(t *testing.T) {
+		t.Helper()
+		assert.NoError(t, c.Server.ServerGroupAddClient(10, 7, 8))
+		assert.Equal(t, `servergroupaddclient sgid=10 cldbid=7|cldbid=8`, lastCmd())
+	}
+
+	servergroupdelclient := func(t *testing.T) {
+		t.Helper()
+		assert.NoError(t, c.Server.ServerGroupDelClient(10, 7))
+		assert.Equal(t, `servergroupdelclient sgid=10 cldbid=7`, lastCmd())
+	}
+
+	servergroupclientlist := func(t *testing.T) {
+		t.Helper()
+		members, err := c.Server.ServerGroupClientList(10, true)
+		if !assert.NoError(t, err) {
+			return
+		}
+
+		expected := []*ServerGroupMember{
+			{DatabaseID: 7, Nickname: "alice", UniqueIdentifier: "abc="},
+			{DatabaseID: 8, Nickname: "bob", UniqueIdentifier: "def="},
+		}
+		assert.Equal(t, expected, members)
+		assert.Equal(t, `servergroupclientlist sgid=10 -names`, lastCmd())
+
+		members, err = c.Server.ServerGroupClientList(11, false)
+		assert.NoError(t, err)
+		assert.Empty(t, members)
+	}
+
+	servergroupsbyclientid := func(t *testing.T) {
+		t.Helper()
+		groups, err := c.Server.ServerGroupsByClientID(7)
+		if !assert.NoError(t, err) {
+			return
+		}
+
+		expected := []*ClientServerGroup{
+			{ID: 2, Name: "Admin Server Query", DatabaseID: 7},
+		}
+		assert.Equal(t, expected, groups)
+	}
+
+	tests := []struct {
+		name string
+		f    func(t *testing.T)
+	}{
+		{"servergroupadd", servergroupadd},
+		{"servergroupdel", servergroupdel},
+		{"servergroupcopy", servergroupcopy},
+		{"servergrouprename", servergrouprename},
+		{"servergroupaddclient", servergroupaddclient},
+		{"servergroupdelclient", servergroupdelclient},
+		{"servergroupclientlist", servergroupclientlist},
+		{"servergroupsbyclientid", servergroupsbyclientid},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, tc.f)
+	}
+}
@@ -0,0 +1,178 @@
+package ts3
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"strings"
+)
+
+// Snapshot is a virtual server snapshot created by SnapshotCreate.
+// Its content is opaque and is kept exactly as sent by the server, one
+// entry per response line, so it can be sent back by SnapshotDeploy.
+type Snapshot struct {
+	lines []string
+}
+
+// String returns the snapshot as sent by the server, with multiple lines
+// separated by a new line.
+func (s *Snapshot) String() string {
+	return strings.Join(s.lines, "\n")
+}
+
+// WriteTo implements io.WriterTo, writing each line of the snapshot
+// followed by a new line.
+func (s *Snapshot) WriteTo(w io.Writer) (int64, error) {
+	n, err := io.WriteString(w, s.String()+"\n")
+	return int64(n), err
+}
+
+// Save writes the snapshot to the file name, creating it with permissions
+// 0600 if needed as snapshots may contain sensitive data.
+func (s *Snapshot) Save(name string) error {
+	if err := ioutil.WriteFile(name, []byte(s.String()+"\n"), 0600); err != nil {
+		return fmt.Errorf("save snapshot: %w", err)
+	}
+	return nil
+}
+
+// ReadSnapshot reads a snapshot written by Snapshot.WriteTo from r.
+func ReadSnapshot(r io.Reader) (*Snapshot, error) {
+	sc := bufio.NewScanner(r)
+	sc.Buffer(make([]byte, startBufSize), MaxParseTokenSize)
+	var lines []string
+	for sc.Scan() {
+		if l := strings.TrimSuffix(sc.Text(), "\r"); l != "" {
+			lines = append(lines, l)
+		}
+	}
+
+	if err := sc.Err(); err != nil {
+		return nil, fmt.Errorf("read snapshot: %w", err)
+	}
+
+	return newSnapshot(lines)
+}
+
+// LoadSnapshot reads a snapshot saved by Snapshot.Save from the file name.
+func LoadSnapshot(name string) (*Snapshot, error) {
+	f, err := os.Open(name)
+	if err != nil {
+		return nil, fmt.Errorf("load snapshot: %w", err)
+	}
+	defer f.Close() //nolint: errcheck
+
+	return ReadSnapshot(f)
+}
+
+// newSnapshot returns a Snapshot from the response lines.
+func newSnapshot(lines []string) (*Snapshot, error) {
+	if len(lines) == 0 {
+		return nil, ErrEmptySnapshot
+	}
+
+	return &Snapshot{lines: append([]string(nil), lines...)}, nil
+}
+
+// rawArg is a CmdArg which is sent without escaping.
+type rawArg string
+
+// ArgString implements CmdArg.
+func (a rawArg) ArgString() string {
+	return string(a)
+}
+
+// SnapshotCreate returns a snapshot of the selected server, including its
+// settings, channels, groups and permissions.
+// If password isn't empty the snapshot is encrypted with it, which is only
+// supported by newer servers.
+func (s *ServerMethods) SnapshotCreate(password string) (*Snapshot, error) {
+	return s.SnapshotCreateContext(context.Background(), password)
+}
+
+// SnapshotCreateContext returns a snapshot of the selected server.
+func (s *ServerMethods) SnapshotCreateContext(ctx context.Context, password string) (*Snapshot, error) {
+	cmd := NewCmd("serversnapshotcreate")
+	if password != "" {
+		cmd.WithArgs(NewArg("password", password))
+	}
+
+	lines, err := s.ExecCmdContext(ctx, cmd)
+	if err != nil {
+		return nil, err
+	}
+
+	return newSnapshot(lines)
+}
+
+// SnapshotDeployOptions configures SnapshotDeploy.
+type SnapshotDeployOptions struct {
+	// Password is the password the snapshot was encrypted with.
+	Password string
+
+	// Mapping requests the mapping of channel IDs in the snapshot to the
+	// IDs of the deployed channels.
+	Mapping bool
+
+	// KeepFiles keeps the files of channels which exist in the snapshot.
+	KeepFiles bool
+}
+
+// SnapshotDeploy restores the selected server from snap.
+// A command can't span multiple lines so snapshots which the server split
+// over multiple lines can't be deployed and ErrMultiLineSnapshot is returned.
+// If opts.Mapping is set the returned map contains the ID of the deployed
+// channel for each channel ID in the snapshot, otherwise it's nil.
+// opts may be nil.
+func (s *ServerMethods) SnapshotDeploy(snap *Snapshot, opts *SnapshotDeployOptions) (map[int]int, error) {
+	return s.SnapshotDeployContext(context.Background(), snap, opts)
+}
+
+// SnapshotDeployContext restores the selected server from snap.
+func (s *ServerMethods) SnapshotDeployContext(ctx context.Context, snap *Snapshot, opts *SnapshotDeployOptions) (map[int]int, error) {
+	if snap == nil || len(snap.lines) == 0 {
+		return nil, ErrEmptySnapshot
+	} else if len(snap.lines) > 1 {
+		return nil, ErrMultiLineSnapshot
+	}
+
+	if opts == nil {
+		opts = &SnapshotDeployOptions{}
+	}
+
+	// Options must precede the snapshot data so are sent as arguments.
+	var args []CmdArg
+	if opts.Mapping {
+		args = append(args, rawArg("-mapping"))
+	}
+	if opts.KeepFiles {
+		args = append(args, rawArg("-keepfiles"))
+	}
+	if opts.Password != "" {
+		args = append(args, NewArg("password", opts.Password))
+	}
+	args = append(args, rawArg(snap.lines[0]))
+
+	lines, err := s.ExecCmdContext(ctx, NewCmd("serversnapshotdeploy").WithArgs(args...))
+	if err != nil || !opts.Mapping || len(lines) == 0 {
+		return nil, err
+	}
+
+	var channels []*struct {
+		Old int `ms:"ocid"`
+		New int `ms:"ncid"`
+	}
+	if err := DecodeResponse(lines, &channels); err != nil {
+		return nil, err
+	}
+
+	mapping := make(map[int]int, len(channels))
+	for _, c := range channels {
+		mapping[c.Old] = c.New
+	}
+
+	return mapping, nil
+}
@@ -0,0 +1,163 @@
+package ts3
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCmdsSnapshot(t *testing.T) {
+	s := newServer(t)
+	defer func() {
+		assert.NoError(t, s.Close())
+	}()
+
+	c, err := NewClient(s.Addr, Timeout(time.Second*2))
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	defer func() {
+		assert.NoError(t, c.Close())
+	}()
+
+	testCmdsSnapshot(t, c, s)
+}
+
+func TestCmdsSnapshotSSH(t *testing.T) {
+	s := newServer(t, useSSH())
+	defer func() {
+		assert.NoError(t, s.Close())
+	}()
+
+	c, err := NewClient(s.Addr, Timeout(time.Second*2), SSH(sshClientTestConfig))
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	defer func() {
+		assert.NoError(t, c.Close())
+	}()
+
+	testCmdsSnapshot(t, c, s)
+}
+
+func testCmdsSnapshot(t *testing.T, c *Client, s *server) {
+	t.Helper()
+	lastCmd := func() string {
+		received := s.Received()
+		return received[len(received)-1]
+	}
+
+	// The mock server splits the snapshot over two lines.
+	lines := []string{
+		`hash=bnTd2E1kNITHjJYRCFjgbKKO5P8= virtualserver_name=Test\sServer`,
+		`channel_id=1 channel_name=Lobby`,
+	}
+	const data = `hash=bnTd2E1kNITHjJYRCFjgbKKO5P8= virtualserver_name=Test\sServer|channel_id=1 channel_name=Lobby`
+
+	serversnapshotcreate := func(t *testing.T) {
+		t.Helper()
+		snap, err := c.Server.SnapshotCreate("")
+		if !assert.NoError(t, err) {
+			return
+		}
+		assert.Equal(t, lines, snap.lines)
+		assert.Equal(t, strings.Join(lines, "\n"), snap.String())
+		assert.Equal(t, "serversnapshotcreate", lastCmd())
+
+		// Multi-line snapshots round trip unaltered.
+		var buf bytes.Buffer
+		_, err = snap.WriteTo(&buf)
+		require.NoError(t, err)
+		loaded, err := ReadSnapshot(&buf)
+		require.NoError(t, err)
+		assert.Equal(t, snap, loaded)
+
+		_, err = c.Server.SnapshotDeploy(snap, nil)
+		assert.Equal(t, ErrMultiLineSnapshot, err)
+
+		snap, err = c.Server.SnapshotCreate("secret")
+		if !assert.NoError(t, err) {
+			return
+		}
+		assert.Equal(t, "version=3 salt=c2FsdA== data=ZW5jcnlwdGVk", snap.String())
+	}
+
+	serversnapshotdeploy := func(t *testing.T) {
+		t.Helper()
+		snap := &Snapshot{lines: []string{data}}
+		mapping, err := c.Server.SnapshotDeploy(snap, nil)
+		if !assert.NoError(t, err) {
+			return
+		}
+		assert.Nil(t, mapping)
+		assert.Equal(t, "serversnapshotdeploy "+data, lastCmd())
+
+		mapping, err = c.Server.SnapshotDeploy(snap, &SnapshotDeployOptions{
+			Password:  "secret",
+			Mapping:   true,
+			KeepFiles: true,
+		})
+		if !assert.NoError(t, err) {
+			return
+		}
+		assert.Equal(t, map[int]int{1: 5, 2: 6}, mapping)
+		assert.Equal(t, "serversnapshotdeploy -mapping -keepfiles password=secret "+data, lastCmd())
+
+		_, err = c.Server.SnapshotDeploy(&Snapshot{}, nil)
+		assert.Equal(t, ErrEmptySnapshot, err)
+	}
+
+	tests := []struct {
+		name string
+		f    func(t *testing.T)
+	}{
+		{"serversnapshotcreate", serversnapshotcreate},
+		{"serversnapshotdeploy", serversnapshotdeploy},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, tc.f)
+	}
+}
+
+func TestSnapshotSaveLoad(t *testing.T) {
+	dir, err := ioutil.TempDir("", "ts3-snapshot")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir) //nolint: errcheck
+
+	snap := &Snapshot{lines: []string{`hash=abc virtualserver_name=Test\sServer|channel_id=1`, `channel_id=2`}}
+	name := filepath.Join(dir, "snapshot.txt")
+	require.NoError(t, snap.Save(name))
+
+	info, err := os.Stat(name)
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0600), info.Mode().Perm())
+
+	loaded, err := LoadSnapshot(name)
+	require.NoError(t, err)
+	assert.Equal(t, snap, loaded)
+
+	var buf bytes.Buffer
+	n, err := snap.WriteTo(&buf)
+	require.NoError(t, err)
+	assert.Equal(t, int64(buf.Len()), n)
+
+	loaded, err = ReadSnapshot(&buf)
+	require.NoError(t, err)
+	assert.Equal(t, snap, loaded)
+
+	_, err = ReadSnapshot(strings.NewReader("\n"))
+	assert.Equal(t, ErrEmptySnapshot, err)
+
+	_, err = LoadSnapshot(filepath.Join(dir, "missing"))
+	assert.Error(t, err)
+}
@@ -0,0 +1,92 @@
+package ts3
+
+import (
+	"context"
+	"fmt"
+	"unicode/utf8"
+)
+
+// MaxTextMessageSize is the maximum size in bytes of an escaped text
+// message accepted by the server. Longer messages are split.
+const MaxTextMessageSize = 1024
+
+// SendTextMessage sends msg to the target specified by mode.
+// For TextMessageTargetClient target is the ID of the client, for
+// TextMessageTargetChannel the ID of the channel and for
+// TextMessageTargetServer the ID of the virtual server.
+// Note: the server only allows channel and server messages to be sent to
+// the channel and server the query client is currently in.
+//
+// Messages longer than MaxTextMessageSize once escaped are sent as
+// multiple messages, split on rune boundaries.
+func (s *ServerMethods) SendTextMessage(mode TextMessageTargetMode, target int, msg string) error {
+	return s.SendTextMessageContext(context.Background(), mode, target, msg)
+}
+
+// SendTextMessageContext sends msg to the target specified by mode.
+func (s *ServerMethods) SendTextMessageContext(ctx context.Context, mode TextMessageTargetMode, target int, msg string) error {
+	if mode < TextMessageTargetClient || mode > TextMessageTargetServer {
+		return fmt.Errorf("send text message: invalid target mode %d", mode)
+	}
+
+	for _, m := range splitTextMessage(msg, MaxTextMessageSize) {
+		if _, err := s.ExecCmdContext(ctx, NewCmd("sendtextmessage").WithArgs(
+			NewArg("targetmode", int(mode)),
+			NewArg("target", target),
+			NewArg("msg", m),
+		)); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// GlobalMessage sends msg to all clients on all virtual servers.
+// Messages longer than MaxTextMessageSize once escaped are sent as
+// multiple messages, split on rune boundaries.
+func (s *ServerMethods) GlobalMessage(msg string) error {
+	return s.GlobalMessageContext(context.Background(), msg)
+}
+
+// GlobalMessageContext sends msg to all clients on all virtual servers.
+func (s *ServerMethods) GlobalMessageContext(ctx context.Context, msg string) error {
+	for _, m := range splitTextMessage(msg, MaxTextMessageSize) {
+		if _, err := s.ExecCmdContext(ctx, NewCmd("gm").WithArgs(NewArg("msg", m))); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// splitTextMessage splits msg into parts which are no longer than max
+// bytes once escaped. Splits only occur on rune boundaries.
+func splitTextMessage(msg string, max int) []string {
+	var parts []string
+	var start, size int
+	for i, r := range msg {
+		n := escapedLen(r, msg[i:])
+		if size+n > max && i > start {
+			parts = append(parts, msg[start:i])
+			start, size = i, 0
+		}
+		size += n
+	}
+
+	return append(parts, msg[start:])
+}
+
+// escapedLen returns the length in bytes, once escaped, of the rune r
+// at the start of s.
+func escapedLen(r rune, s string) int {
+	switch r {
+	case '\\', '/', ' ', '|', '\a', '\b', '\f', '\n', '\r', '\t', '\v':
+		return 2
+	}
+
+	// Use the encoded width so invalid bytes, which are decoded as
+	// utf8.RuneError, are counted as sent.
+	_, n := utf8.DecodeRuneInString(s)
+	return n
+}
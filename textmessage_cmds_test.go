@@ -0,0 +1,138 @@
+package ts3
+
+import (
+	"strings"
+	"testing"
+	"time"
+	"unicode/utf8"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCmdsTextMessage(t *testing.T) {
+	s := newServer(t)
+	defer func() {
+		assert.NoError(t, s.Close())
+	}()
+
+	c, err := NewClient(s.Addr, Timeout(time.Second*2))
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	defer func() {
+		assert.NoError(t, c.Close())
+	}()
+
+	testCmdsTextMessage(t, c, s)
+}
+
+func TestCmdsTextMessageSSH(t *testing.T) {
+	s := newServer(t, useSSH())
+	defer func() {
+		assert.NoError(t, s.Close())
+	}()
+
+	c, err := NewClient(s.Addr, Timeout(time.Second*2), SSH(sshClientTestConfig))
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	defer func() {
+		assert.NoError(t, c.Close())
+	}()
+
+	testCmdsTextMessage(t, c, s)
+}
+
+func testCmdsTextMessage(t *testing.T, c *Client, s *server) {
+	t.Helper()
+	lastCmd := func() string {
+		received := s.Received()
+		return received[len(received)-1]
+	}
+
+	sendtextmessage := func(t *testing.T) {
+		t.Helper()
+		assert.NoError(t, c.Server.SendTextMessage(TextMessageTargetClient, 5, "hello world"))
+		assert.Equal(t, `sendtextmessage targetmode=1 target=5 msg=hello\sworld`, lastCmd())
+
+		sent := len(s.Received())
+		msg := strings.Repeat("a", MaxTextMessageSize) + "b"
+		assert.NoError(t, c.Server.SendTextMessage(TextMessageTargetChannel, 1, msg))
+		received := s.Received()
+		if assert.Len(t, received, sent+2) {
+			assert.Equal(t, "sendtextmessage targetmode=2 target=1 msg="+msg[:MaxTextMessageSize], received[sent])
+			assert.Equal(t, "sendtextmessage targetmode=2 target=1 msg=b", received[sent+1])
+		}
+
+		assert.Error(t, c.Server.SendTextMessage(TextMessageTargetMode(4), 1, "x"))
+	}
+
+	gm := func(t *testing.T) {
+		t.Helper()
+		assert.NoError(t, c.Server.GlobalMessage("server restart"))
+		assert.Equal(t, `gm msg=server\srestart`, lastCmd())
+	}
+
+	tests := []struct {
+		name string
+		f    func(t *testing.T)
+	}{
+		{"sendtextmessage", sendtextmessage},
+		{"gm", gm},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, tc.f)
+	}
+}
+
+func TestSplitTextMessage(t *testing.T) {
+	tests := map[string]struct {
+		msg      string
+		max      int
+		expected []string
+	}{
+		"empty": {
+			msg:      "",
+			max:      4,
+			expected: []string{""},
+		},
+		"short": {
+			msg:      "abcd",
+			max:      4,
+			expected: []string{"abcd"},
+		},
+		"long": {
+			msg:      "abcdefghij",
+			max:      4,
+			expected: []string{"abcd", "efgh", "ij"},
+		},
+		"escaped": {
+			msg:      "a b|c",
+			max:      4,
+			expected: []string{"a b", "|c"},
+		},
+		"multibyte": {
+			msg:      "aé€",
+			max:      4,
+			expected: []string{"aé", "€"},
+		},
+		"rune-larger-than-max": {
+			msg:      "€€",
+			max:      2,
+			expected: []string{"€", "€"},
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			parts := splitTextMessage(tc.msg, tc.max)
+			assert.Equal(t, tc.expected, parts)
+			for _, p := range parts {
+				assert.True(t, utf8.ValidString(p))
+			}
+		})
+	}
+}
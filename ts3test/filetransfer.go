@@ -0,0 +1,176 @@
+// Package ts3test provides utilities for testing code which uses the
+// TeamSpeak 3 file transfer API.
+//
+// FileTransferServer is a local file transfer listener which can stand in
+// for the file transfer port of a TeamSpeak 3 server e.g.
+//
+//	ft, err := ts3test.NewFileTransferServer()
+//	if err != nil {
+//		t.Fatal(err)
+//	}
+//	defer ft.Close()
+//
+//	ft.AddDownload("0123456789abcdef", []byte("hello world"))
+//
+// The ftinitdownload response, returning the key and ft.Port, is then
+// provided by a mock ServerQuery server.
+package ts3test
+
+import (
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net"
+	"sync"
+)
+
+// KeyLen is the length of the file transfer keys used by FileTransferServer.
+const KeyLen = 16
+
+// FileTransferServer is a mock TeamSpeak 3 file transfer server.
+type FileTransferServer struct {
+	Listener net.Listener
+	Port     int
+
+	wg sync.WaitGroup
+
+	// Below here is protected by mtx.
+	mtx       sync.Mutex
+	uploads   map[string][]byte
+	downloads map[string][]byte
+	closed    bool
+	err       error
+}
+
+// NewFileTransferServer returns a running file transfer server listening
+// on a local port.
+func NewFileTransferServer() (*FileTransferServer, error) {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		if l, err = net.Listen("tcp6", "[::1]:0"); err != nil {
+			return nil, fmt.Errorf("file transfer server: listen: %w", err)
+		}
+	}
+
+	s := &FileTransferServer{
+		Listener:  l,
+		Port:      l.Addr().(*net.TCPAddr).Port,
+		uploads:   make(map[string][]byte),
+		downloads: make(map[string][]byte),
+	}
+	s.wg.Add(1)
+	go s.serve()
+
+	return s, nil
+}
+
+// AddUpload registers key, which must be KeyLen bytes, as an upload.
+func (s *FileTransferServer) AddUpload(key string) {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	s.uploads[key] = nil
+}
+
+// AddDownload registers key, which must be KeyLen bytes, as a download of data.
+func (s *FileTransferServer) AddDownload(key string, data []byte) {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	s.downloads[key] = data
+}
+
+// Uploaded returns the data uploaded with key.
+// Call Close first to ensure the upload has completed.
+func (s *FileTransferServer) Uploaded(key string) []byte {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	return s.uploads[key]
+}
+
+// serve accepts file transfer connections.
+func (s *FileTransferServer) serve() {
+	defer s.wg.Done()
+
+	for {
+		conn, err := s.Listener.Accept()
+		if err != nil {
+			return
+		}
+
+		s.wg.Add(1)
+		go s.handle(conn)
+	}
+}
+
+// handle transfers the data for the key sent on conn.
+func (s *FileTransferServer) handle(conn net.Conn) {
+	defer s.wg.Done()
+	defer conn.Close() //nolint: errcheck
+
+	if err := s.transfer(conn); err != nil {
+		s.mtx.Lock()
+		s.err = err
+		s.mtx.Unlock()
+	}
+}
+
+// transfer reads the key from conn and then receives or sends its data.
+func (s *FileTransferServer) transfer(conn net.Conn) error {
+	key := make([]byte, KeyLen)
+	if _, err := io.ReadFull(conn, key); err != nil {
+		return fmt.Errorf("read key: %w", err)
+	}
+
+	s.mtx.Lock()
+	_, upload := s.uploads[string(key)]
+	data, download := s.downloads[string(key)]
+	s.mtx.Unlock()
+
+	switch {
+	case upload:
+		data, err := ioutil.ReadAll(conn)
+		if err != nil {
+			return fmt.Errorf("upload: %w", err)
+		}
+
+		s.mtx.Lock()
+		s.uploads[string(key)] = data
+		s.mtx.Unlock()
+	case download:
+		if _, err := conn.Write(data); err != nil {
+			return fmt.Errorf("download: %w", err)
+		}
+	default:
+		return fmt.Errorf("unknown key %q", key)
+	}
+
+	return nil
+}
+
+// Close stops the server and waits for all transfers to complete.
+// It returns the error of the last failed transfer, if any.
+// It's safe to call more than once.
+func (s *FileTransferServer) Close() error {
+	s.mtx.Lock()
+	closed := s.closed
+	s.closed = true
+	s.mtx.Unlock()
+
+	if closed {
+		return nil
+	}
+
+	err := s.Listener.Close()
+	s.wg.Wait()
+
+	if err != nil {
+		return fmt.Errorf("file transfer server: close: %w", err)
+	}
+
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	return s.err
+}
@@ -0,0 +1,58 @@
+package ts3test
+
+import (
+	"io"
+	"io/ioutil"
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFileTransferServer(t *testing.T) {
+	s, err := NewFileTransferServer()
+	require.NoError(t, err)
+	defer s.Close() //nolint: errcheck
+
+	const (
+		upKey   = "upload0000000000"
+		downKey = "download00000000"
+	)
+	s.AddUpload(upKey)
+	s.AddDownload(downKey, []byte("hello"))
+
+	conn, err := net.Dial("tcp", s.Listener.Addr().String())
+	require.NoError(t, err)
+	_, err = io.WriteString(conn, upKey+"world")
+	require.NoError(t, err)
+	require.NoError(t, conn.Close())
+
+	conn, err = net.Dial("tcp", s.Listener.Addr().String())
+	require.NoError(t, err)
+	_, err = io.WriteString(conn, downKey)
+	require.NoError(t, err)
+	data, err := ioutil.ReadAll(conn)
+	require.NoError(t, err)
+	require.NoError(t, conn.Close())
+	assert.Equal(t, "hello", string(data))
+
+	require.NoError(t, s.Close())
+	assert.Equal(t, "world", string(s.Uploaded(upKey)))
+	assert.NoError(t, s.Close())
+}
+
+func TestFileTransferServerUnknownKey(t *testing.T) {
+	s, err := NewFileTransferServer()
+	require.NoError(t, err)
+
+	conn, err := net.Dial("tcp", s.Listener.Addr().String())
+	require.NoError(t, err)
+	_, err = io.WriteString(conn, "unknown000000000")
+	require.NoError(t, err)
+	_, err = ioutil.ReadAll(conn)
+	require.NoError(t, err)
+	require.NoError(t, conn.Close())
+
+	assert.Error(t, s.Close())
+}